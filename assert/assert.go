@@ -2,6 +2,7 @@
 package assert
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -12,109 +13,357 @@ import (
 )
 
 // Violation represents the constraint that failed an assertion. Field is the name of the field that failed an
-// assertion and Constraint is the assertion type that was used to validate that field.
+// assertion and Constraint is the assertion type that was used to validate that field. Code, when set, identifies
+// which rule of the constraint failed, e.g. bidi_control, and Message explains the failure, e.g. by naming the field
+// that triggered a conditional constraint.
 type Violation struct {
 	Field      string
 	Constraint string
+	Code       string
+	Message    string
 }
 
 // The assertFns map contains the validation functions as values each associated with the validation name as the key.
 var assertFns = map[string]func(assertions map[string]string, v reflect.Value, n string, vs *[]Violation, path string) *[]Violation{
-	"required":  assertRequired,
-	"min":       assertMin,
-	"max":       assertMax,
-	"pattern":   assertPattern,
-	"maxlength": assertMaxLength,
-	"minlength": assertMinLength,
-}
-
-// Assert is used to validate a struct's field. It returns a slice of Violation elements.
-func Assert(ifc interface{}) []Violation {
-	violations := make([]Violation, 0)
-	assertAll(ifc, &violations, "")
-	return violations
+	"min":         assertMin,
+	"max":         assertMax,
+	"gt":          assertGt,
+	"lt":          assertLt,
+	"multipleof":  assertMultipleOf,
+	"pattern":     assertPattern,
+	"maxlength":   assertMaxLength,
+	"minlength":   assertMinLength,
+	"oneof":       assertOneOf,
+	"oneofci":     assertOneOf,
+	"enum":        assertEnum,
+	"format":      assertFormat,
+	"layout":      assertLayout,
+	"charset":     assertCharset,
+	"script":      assertScript,
+	"category":    assertCategory,
+	"trimmed":     assertTrimmed,
+	"normalized":  assertNormalized,
+	"invisible":   assertInvisible,
+	"bidi":        assertBidi,
+	"restriction": assertRestriction,
+	"confusable":  assertConfusable,
+	"identifier":  assertIdentifier,
+	"country":     assertCountry,
+	"currency":    assertCurrency,
+	"language":    assertLanguage,
+	"latitude":    assertLatitude,
+	"longitude":   assertLongitude,
+	"geojson":     assertGeoJSON,
+}
+
+// The fieldFns map contains the validation functions that depend on the state of the current run, such as the
+// presence information recorded by DecodeJSON. They take precedence over the functions in assertFns.
+var fieldFns = map[string]func(assertions map[string]string, f field, s *scope){
+	"required":  assertPresent,
+	"forbidden": assertForbidden,
+	"pattern":   assertPatternMatch,
+	"nilable":   assertNilable,
+	"eqfield":   assertCompareField("eqfield", func(c int) bool { return c == 0 }),
+	"nefield":   assertCompareField("nefield", func(c int) bool { return c != 0 }),
+	"gtfield":   assertCompareField("gtfield", func(c int) bool { return c > 0 }),
+	"gtefield":  assertCompareField("gtefield", func(c int) bool { return c >= 0 }),
+	"ltfield":   assertCompareField("ltfield", func(c int) bool { return c < 0 }),
+	"ltefield":  assertCompareField("ltefield", func(c int) bool { return c <= 0 }),
+
+	"required_if":      assertRequiredIf,
+	"required_unless":  assertRequiredUnless,
+	"required_with":    assertRequiredWith,
+	"required_without": assertRequiredWithout,
+	"excluded_if":      assertExcludedIf,
+	"excluded_with":    assertExcludedWith,
+
+	"after":  assertAfter,
+	"before": assertBefore,
+	"past":   assertPast,
+	"future": assertFuture,
+
+	"postalcode":  assertPostalCode,
+	"subdivision": assertSubdivision,
+	"phone":       assertPhone,
+	"password":    assertPassword,
+}
+
+// The presenceConstraints map contains the constraints that check whether a field is set rather than its value. They
+// are asserted against the field itself, while the other constraints are asserted against the value its pointers
+// point to and are skipped when a pointer is nil.
+var presenceConstraints = map[string]bool{
+	"required":         true,
+	"forbidden":        true,
+	"nilable":          true,
+	"required_if":      true,
+	"required_unless":  true,
+	"required_with":    true,
+	"required_without": true,
+	"excluded_if":      true,
+	"excluded_with":    true,
+}
+
+// field describes the struct field being asserted.
+type field struct {
+	parent   reflect.Value
+	index    int
+	val      reflect.Value
+	name     string
+	path     string
+	presence *Presence
+}
+
+// scope holds the state shared by the assertions of a single run.
+type scope struct {
+	violations *[]Violation
+	decoded    bool
+	paths      map[string]bool
+	groups     map[string]bool
+	parents    []reflect.Value
+	ctx        context.Context
+	clock      Clock
+	fullMatch  bool
+
+	// normalizations replace the value of the field being asserted once all its constraints have been asserted
+	normalizations []func()
+}
+
+// Assert is used to validate a struct's field. It returns a slice of Violation elements. Only the constraints in the
+// given groups are asserted; with no groups, the constraints in DefaultGroup are asserted.
+func Assert(ifc interface{}, groups ...string) []Violation {
+	return defaultValidator.Assert(ifc, groups...)
+}
+
+// AssertContext is used to validate a struct's field like Assert. The context is passed to the struct-level rules of
+// the structs implementing Asserter and of the types registered with RegisterAsserter, and can set the time used by
+// the time constraints with WithNow.
+func AssertContext(ctx context.Context, ifc interface{}, groups ...string) []Violation {
+	return defaultValidator.AssertContext(ctx, ifc, groups...)
+}
+
+// AssertPresence is used to validate a struct decoded by DecodeJSON. The required and forbidden assertions are
+// evaluated against the fields present in the decoded document rather than against the field values.
+func AssertPresence(ifc interface{}, p *Presence, groups ...string) []Violation {
+	return defaultValidator.AssertPresence(ifc, p, groups...)
+}
+
+// AssertPresenceContext is used to validate a struct decoded by DecodeJSON like AssertPresence. The context is used
+// like the context given to AssertContext.
+func AssertPresenceContext(ctx context.Context, ifc interface{}, p *Presence, groups ...string) []Violation {
+	return defaultValidator.AssertPresenceContext(ctx, ifc, p, groups...)
+}
+
+// AssertPartial is used to validate the fields of a partial update. Only the fields at the given paths and the
+// fields nested within them are asserted. Paths use the same syntax as Violation.Field, e.g. Person.LastName, and
+// may be taken from a field mask or from a JSON Merge Patch decoded with DecodeJSON.
+func AssertPartial(ifc interface{}, paths []string, groups ...string) []Violation {
+	return defaultValidator.AssertPartial(ifc, paths, groups...)
+}
+
+// AssertPartialContext is used to validate the fields of a partial update like AssertPartial. The context is used
+// like the context given to AssertContext.
+func AssertPartialContext(ctx context.Context, ifc interface{}, paths []string, groups ...string) []Violation {
+	return defaultValidator.AssertPartialContext(ctx, ifc, paths, groups...)
 }
 
 func assertAll(ifc interface{}, violations *[]Violation, path string) {
-	v := reflect.ValueOf(ifc)
-	t := reflect.TypeOf(ifc)
+	s := defaultValidator.scope(context.Background(), violations, nil)
+	s.walk(reflect.ValueOf(ifc), path, nil, true)
+}
+
+// walk asserts the fields of the struct v and the rest of its object graph. When all is false only the fields
+// selected by a partial run, and the fields nested within them, are asserted.
+func (s *scope) walk(v reflect.Value, path string, p *Presence, all bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	// time.Time is asserted as a value rather than walked as a struct
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return
+	}
+
+	t := v.Type()
+
+	// report the faulty tags of the struct type once
+	checkTypeTags(t)
 
 	// set the path to the current struct
 	path = asPath(path, t)
 
+	// keep track of the enclosing structs for constraints that reference other fields
+	s.parents = append(s.parents, v)
+	defer func() { s.parents = s.parents[:len(s.parents)-1] }()
+
 	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		fp := p.field(name)
+		selected := all || s.paths[asQualifiedPath(path, name)]
+
 		// assert the struct's fields
-		violations = validate(v, i, violations, path)
+		if selected {
+			s.validate(field{parent: v, index: i, val: v.Field(i), name: name, path: path, presence: fp})
+		}
 
 		// walk the rest of the object graph
 		switch v.Field(i).Kind() {
-		case reflect.Struct:
-			fieldValue := v.Field(i).Interface()
-
-			// set parent path
-			assertAll(fieldValue, violations, path)
+		case reflect.Struct, reflect.Ptr, reflect.Interface:
+			s.walk(v.Field(i), path, fp, selected)
 		case reflect.Slice, reflect.Array:
 			slice := v.Field(i)
 
 			for idx := 0; idx < slice.Len(); idx++ {
-				el := slice.Index(idx)
-
-				if !isNilOrEmpty(el) && el.Kind() != reflect.Struct {
-					assertAll(slice.Index(idx).Elem().Interface(), violations, path)
-				} else {
-					assertAll(slice.Index(idx).Interface(), violations, path)
-				}
-			}
-		case reflect.Ptr:
-			if !isNilOrEmpty(v.Field(i)) {
-				fieldValue := v.Field(i).Elem().Interface()
-
-				assertAll(fieldValue, violations, path)
+				s.walk(slice.Index(idx), path, fp.elem(idx), selected)
 			}
 		default:
 			// todo
 		}
 	}
+
+	// assert the rules that span the struct's fields
+	if all {
+		s.assertSelf(v, path)
+	}
 }
 
-func validate(v reflect.Value, fieldIndex int, violations *[]Violation, path string) *[]Violation {
-	tag := v.Type().Field(fieldIndex).Tag
-	val := v.Field(fieldIndex)
-	name := v.Type().Field(fieldIndex).Name
+func (s *scope) validate(f field) {
+	tag := f.parent.Type().Field(f.index).Tag
 
 	// get a map of assertions to assert
 	assertions := asAssertions(tag)
 
-	for assertion, _ := range assertions {
-		if fnValidation, ok := assertFns[assertion]; ok {
-			violations = fnValidation(assertions, val, name, violations, path)
+	// the constraints on the value see through pointers and skip a nil pointer
+	value := f
+	value.val = indirect(f.val)
+
+	start := len(*s.violations)
+
+	for assertion := range assertions {
+		if !s.inGroups(assertions, assertion) {
+			continue
+		}
+
+		target := value
+		if presenceConstraints[assertion] {
+			target = f
+		} else if !value.val.IsValid() {
+			continue
+		}
+
+		if fnField, ok := fieldFns[assertion]; ok {
+			fnField(assertions, target, s)
+		} else if fnValidation, ok := assertFns[assertion]; ok {
+			s.violations = fnValidation(assertions, target.val, target.name, s.violations, target.path)
 		}
 	}
 
-	return violations
+	for _, normalize := range s.normalizations {
+		normalize()
+	}
+
+	s.normalizations = s.normalizations[:0]
+
+	// the messages of the other constraints on a password may quote it
+	if _, ok := assertions["password"]; ok {
+		redact(*s.violations, start)
+	}
+}
+
+// redact clears the messages of the violations from the index start that weren't reported by the password
+// assertion, whose messages never include the value.
+func redact(violations []Violation, start int) {
+	for i := start; i < len(violations); i++ {
+		if violations[i].Constraint != "password" {
+			violations[i].Message = ""
+		}
+	}
+}
+
+// report appends a violation of the constraint by the field f.
+func (s *scope) report(f field, constraint string) {
+	s.reportMessage(f, constraint, "")
+}
+
+// reportMessage appends a violation of the constraint by the field f explained by message.
+func (s *scope) reportMessage(f field, constraint string, message string) {
+	s.reportCode(f, constraint, "", message)
+}
+
+// reportCode appends a violation of the constraint by the field f identified by code and explained by message.
+func (s *scope) reportCode(f field, constraint string, code string, message string) {
+	violation := Violation{Field: asQualifiedPath(f.path, f.name), Constraint: constraint, Code: code, Message: message}
+	*s.violations = append(*s.violations, violation)
 }
 
 func asAssertions(tag reflect.StructTag) map[string]string {
 	checks := make(map[string]string)
 
 	if t, ok := tag.Lookup("assert"); ok {
-		assertTags := strings.Split(t, ":")
-		tagPairs := strings.Split(assertTags[0], ",")
-
-		for _, pair := range tagPairs {
+		for _, pair := range asPairs(t) {
 			key, value, err := asKeyValue(pair)
 
 			if err != nil {
 				fmt.Println(err)
 			}
 
-			checks[key] = value
+			// options follow the value, e.g. required=true;groups=create, and are keyed as required;groups
+			options := asOptions(value)
+			checks[key] = options[0]
+
+			for _, option := range options[1:] {
+				name, optionValue, _ := asKeyValue(option)
+				checks[key+";"+name] = optionValue
+			}
 		}
 	}
 
 	return checks
 }
 
+// asPairs splits the assert tag into its constraint pairs at each comma, except an escaped \, which is part of the
+// pair, so that a pattern such as ^\d{1\,3}$ is kept whole.
+func asPairs(tag string) []string {
+	pairs := make([]string, 0, 1)
+	start := 0
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			i++
+		case tag[i] == ',':
+			pairs = append(pairs, strings.ReplaceAll(tag[start:i], `\,`, ","))
+			start = i + 1
+		}
+	}
+
+	return append(pairs, strings.ReplaceAll(tag[start:], `\,`, ","))
+}
+
+// The optionPattern matches the start of an option, its name followed by =.
+var optionPattern = regexp.MustCompile(`^[a-z]+=`)
+
+// asOptions splits the value of a constraint from its options. A ; starts an option only when it is followed by the
+// name of an option and =, so that a pattern such as ^[^;]+$ is kept whole. An escaped \; is always part of the value.
+func asOptions(value string) []string {
+	options := make([]string, 0, 1)
+	start := 0
+
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value) && value[i+1] == ';':
+			i++
+		case value[i] == ';' && optionPattern.MatchString(value[i+1:]):
+			options = append(options, strings.ReplaceAll(value[start:i], `\;`, ";"))
+			start = i + 1
+		}
+	}
+
+	return append(options, strings.ReplaceAll(value[start:], `\;`, ";"))
+}
+
 // assertRequired checks that the value exists and is not empty.
 func assertRequired(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
 	if required, ok := assertions["required"]; ok {
@@ -127,92 +376,82 @@ func assertRequired(assertions map[string]string, val reflect.Value, name string
 	return violations
 }
 
-// assertMin checks that the value is not less than the minimum value.
-func assertMin(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
-	if _, ok := assertions["min"]; ok {
-		switch val.Type().Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-
-			min, err := strconv.ParseInt(assertions["min"], 10, 64)
-
-			if err != nil {
-				log.Printf("%s:%+v", "unable to parse min tag value", err)
-			}
+// assertNilable checks, when nilable=false, that the field isn't a nil pointer or interface, or a pointer to one.
+func assertNilable(assertions map[string]string, f field, s *scope) {
+	if assertions["nilable"] != "false" {
+		return
+	}
 
-			fieldValue := val.Int()
+	switch f.val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !indirect(f.val).IsValid() {
+			s.reportMessage(f, "nilable", "must not be nil")
+		}
+	}
+}
 
-			if !isNilOrEmpty(val) && fieldValue < min {
-				violation := Violation{Field: asQualifiedPath(path, name), Constraint: "min"}
-				*violations = append(*violations, violation)
-			}
-		case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+// assertPresent checks that the field was present in the decoded document. When no document was decoded it falls
+// back to assertRequired.
+func assertPresent(assertions map[string]string, f field, s *scope) {
+	if !s.decoded {
+		s.violations = assertRequired(assertions, f.val, f.name, s.violations, f.path)
+		return
+	}
 
-			min, err := strconv.ParseFloat(assertions["min"], 64)
+	if assertions["required"] == "true" && (f.presence == nil || f.presence.null) {
+		s.report(f, "required")
+	}
+}
 
-			if err != nil {
-				log.Printf("%s:%+v", "unable to parse min tag value", err)
-			}
+// assertForbidden checks that the field was not present in the decoded document. When no document was decoded it
+// checks that the value is the zero value of its type.
+func assertForbidden(assertions map[string]string, f field, s *scope) {
+	if assertions["forbidden"] != "true" {
+		return
+	}
 
-			fieldValue := val.Float()
+	if s.decoded && f.presence != nil || !s.decoded && !f.val.IsZero() {
+		s.report(f, "forbidden")
+	}
+}
 
-			if !isNilOrEmpty(val) && fieldValue < min {
-				violation := Violation{Field: asQualifiedPath(path, name), Constraint: "min"}
-				*violations = append(*violations, violation)
-			}
-		default:
-			log.Printf("invalid field type used with min validation")
-		}
+// assertMin checks that the value is not less than the minimum value. A time.Duration is compared with a bound in
+// the Go duration syntax and other numbers are compared exactly.
+func assertMin(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if _, ok := assertions["min"]; ok && val.IsValid() && val.Type() == durationType {
+		return assertDuration(assertions, "min", val, name, violations, path)
 	}
 
-	return violations
+	return assertBound(assertions, "min", func(c int) bool { return c >= 0 }, val, name, violations, path)
 }
 
-// assertMax checks that the value is not greater than the maximum value.
+// assertMax checks that the value is not greater than the maximum value. A time.Duration is compared with a bound in
+// the Go duration syntax and other numbers are compared exactly.
 func assertMax(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
-	if _, ok := assertions["max"]; ok {
-		switch val.Type().Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-
-			max, err := strconv.ParseInt(assertions["max"], 10, 64)
-
-			if err != nil {
-				log.Printf("%s:%+v", "unable to parse max tag value", err)
-			}
-
-			fieldValue := val.Int()
+	if _, ok := assertions["max"]; ok && val.IsValid() && val.Type() == durationType {
+		return assertDuration(assertions, "max", val, name, violations, path)
+	}
 
-			if !isNilOrEmpty(val) && fieldValue > max {
-				violation := Violation{Field: asQualifiedPath(path, name), Constraint: "max"}
-				*violations = append(*violations, violation)
-			}
-		case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+	return assertBound(assertions, "max", func(c int) bool { return c <= 0 }, val, name, violations, path)
+}
 
-			max, err := strconv.ParseFloat(assertions["max"], 64)
+// Checks that the field value, a string, matches the regular expression specified. A pattern that doesn't compile is
+// skipped; it's reported once as a TagError when its struct type is first asserted.
+func assertPattern(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if _, ok := assertions["pattern"]; ok {
+		value, ok := asStringValue(val)
 
-			if err != nil {
-				log.Printf("%s:%+v", "unable to parse max tag value", err)
-			}
+		if !ok {
+			return violations
+		}
 
-			fieldValue := val.Float()
+		re, err := compilePattern(assertions)
 
-			if !isNilOrEmpty(val) && fieldValue > max {
-				violation := Violation{Field: asQualifiedPath(path, name), Constraint: "max"}
-				*violations = append(*violations, violation)
-			}
-		default:
-			log.Printf("invalid field type used with max validation")
+		if err != nil {
+			return violations
 		}
-	}
 
-	return violations
-}
-
-// Checks that the field value, a string, matches the regular expression specified.
-func assertPattern(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
-	if pattern, ok := assertions["pattern"]; ok {
-		if matched, _ := regexp.MatchString(pattern, val.Interface().(string)); !matched {
+		if !re.MatchString(value) {
 			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "pattern"}
 			*violations = append(*violations, violation)
 		}
@@ -221,7 +460,7 @@ func assertPattern(assertions map[string]string, val reflect.Value, name string,
 	return violations
 }
 
-// Checks that the length of the field of type string is no longer than the value specified.
+// Checks that the length of the field of type string, in bytes, is no longer than the value specified.
 func assertMaxLength(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
 	if m, ok := assertions["maxlength"]; ok {
 		maxLength, err := strconv.Atoi(m)
@@ -230,7 +469,7 @@ func assertMaxLength(assertions map[string]string, val reflect.Value, name strin
 			log.Printf("%s:%+v", "unable to parse maxlength tag value", err)
 		}
 
-		if !isNilOrEmpty(val) && len(val.Interface().(string)) > maxLength {
+		if value, ok := asStringValue(val); ok && value != "" && len(value) > maxLength {
 			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "maxlength"}
 			*violations = append(*violations, violation)
 		}
@@ -239,7 +478,7 @@ func assertMaxLength(assertions map[string]string, val reflect.Value, name strin
 	return violations
 }
 
-// Checks that the length of the field of type string is no shorter than the value specified.
+// Checks that the length of the field of type string, in bytes, is no shorter than the value specified.
 func assertMinLength(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
 	if m, ok := assertions["minlength"]; ok {
 		minLength, err := strconv.Atoi(m)
@@ -248,7 +487,7 @@ func assertMinLength(assertions map[string]string, val reflect.Value, name strin
 			log.Printf("%s:%+v", "unable to parse minlength tag value", err)
 		}
 
-		if !isNilOrEmpty(val) && len(val.Interface().(string)) < minLength {
+		if value, ok := asStringValue(val); ok && value != "" && len(value) < minLength {
 			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "minlength"}
 			*violations = append(*violations, violation)
 		}
@@ -269,6 +508,15 @@ func asQualifiedPath(path string, name string) string {
 
 // Returns true if the value is nil or empty.
 func isNilOrEmpty(v reflect.Value) bool {
+	// an optional wrapper is empty when it isn't set, e.g. a sql.NullString with Valid set to false
+	if _, ok := unwrap(v); ok {
+		return !indirect(v).IsValid()
+	}
+
+	if t, ok := asTime(v); ok {
+		return t.IsZero()
+	}
+
 	switch v.Type().Kind() {
 	case reflect.Bool:
 		return !v.Bool()
@@ -301,7 +549,7 @@ func isNilOrEmpty(v reflect.Value) bool {
 		}
 		return false
 	default:
-		panic(&reflect.ValueError{"unknown value kind", v.Kind()})
+		panic(&reflect.ValueError{Method: "unknown value kind", Kind: v.Kind()})
 	}
 }
 
@@ -317,7 +565,7 @@ func asPath(path string, t reflect.Type) string {
 
 // Takes string pair string and sep string used to execute a split on and returns key, value and error values.
 func asKeyValue(pair string) (string, string, error) {
-	keyValue := strings.Split(pair, "=")
+	keyValue := strings.SplitN(pair, "=", 2)
 
 	if len(keyValue) != 2 {
 		return "", "", errors.New("pair doesn't contain both a key and value")
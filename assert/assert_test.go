@@ -421,6 +421,15 @@ func TestAsAssertions(t *testing.T) {
 			},
 			expected: map[string]string{},
 		},
+		{
+			name: "scenario3",
+			args: args{
+				tag: reflect.TypeOf(struct {
+					Count int `assert:"required=true;groups=create|update,max=10"`
+				}{}).Field(0).Tag,
+			},
+			expected: map[string]string{"required": "true", "required;groups": "create|update", "max": "10"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -603,6 +612,80 @@ func TestAssertAll(t *testing.T) {
 	}
 }
 
+func TestAssertPartial(t *testing.T) {
+	person := Person{
+		FirstName: "James",
+		Address: []*Address{
+			{
+				Address1: "755 Crossover Lane",
+				State:    "TN",
+				Location: &Location{
+					Latitude: &Latitude{
+						Degrees:   135.1098212,
+						Direction: "N",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			paths:    []string{"Person.FirstName"},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			paths:    []string{"Person.FirstName", "Person.LastName"},
+			expected: &[]Violation{{Field: "Person.LastName", Constraint: "required"}},
+		},
+		{
+			name:     "scenario3",
+			paths:    []string{"Person.Address.Location"},
+			expected: &[]Violation{{Field: "Person.Address.Location.Latitude.Degrees", Constraint: "max"}},
+		},
+		{
+			name:     "scenario4",
+			paths:    []string{},
+			expected: &[]Violation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := AssertPartial(person, tt.paths); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("AssertPartial() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+// sameViolations returns true if a and b hold the same violations in any order.
+func sameViolations(a []Violation, b []Violation) bool {
+	counts := make(map[Violation]int)
+
+	for _, v := range a {
+		counts[v]++
+	}
+
+	for _, v := range b {
+		counts[v]--
+	}
+
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+
+	return len(a) == len(b)
+}
+
 type Latitude struct {
 	Degrees   float64 `json:"degrees" assert:"required=true,min=0.0,max=90.0"`
 	Direction string  `json:"direction" assert:"required=true,pattern=^(N|S)$"`
@@ -0,0 +1,64 @@
+package assert
+
+import (
+	"context"
+	"reflect"
+)
+
+// Asserter is implemented by structs with rules that span several fields, e.g. at least one contact method must be
+// set. The Field of each returned violation is relative to the struct and is prefixed with the struct's path. A
+// violation with an empty Field applies to the struct itself.
+type Asserter interface {
+	AssertSelf(ctx context.Context) []Violation
+}
+
+// AsserterFunc is a struct-level rule registered for a type with RegisterAsserter. v holds a value of that type.
+type AsserterFunc func(ctx context.Context, v interface{}) []Violation
+
+// The asserterFns map contains the struct-level rules registered for types that can't implement Asserter.
+var asserterFns = map[reflect.Type]AsserterFunc{}
+
+// RegisterAsserter registers the struct-level rule of the type of v, e.g. a type from a third-party package. It
+// replaces any rule registered for the type, so a type has at most one. It should be called during initialization.
+func RegisterAsserter(v interface{}, fn AsserterFunc) {
+	asserterFns[reflect.TypeOf(v)] = fn
+}
+
+// assertSelf asserts the struct-level rules of the struct v and merges their violations under path.
+func (s *scope) assertSelf(v reflect.Value, path string) {
+	if !v.CanInterface() {
+		return
+	}
+
+	// rules declared on a pointer receiver are found through the struct's address, or through a copy when the struct
+	// isn't addressable
+	var ptr reflect.Value
+
+	if v.CanAddr() {
+		ptr = v.Addr()
+	} else {
+		ptr = reflect.New(v.Type())
+		ptr.Elem().Set(v)
+	}
+
+	if asserter, ok := ptr.Interface().(Asserter); ok {
+		s.merge(asserter.AssertSelf(s.ctx), path)
+	}
+
+	if fn, ok := asserterFns[v.Type()]; ok {
+		s.merge(fn(s.ctx, v.Interface()), path)
+	}
+}
+
+// merge appends the violations returned by a struct-level rule, prefixing their fields with path.
+func (s *scope) merge(violations []Violation, path string) {
+	for _, violation := range violations {
+		if violation.Field == "" {
+			violation.Field = path
+		} else {
+			violation.Field = asQualifiedPath(path, violation.Field)
+		}
+
+		*s.violations = append(*s.violations, violation)
+	}
+}
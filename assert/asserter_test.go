@@ -0,0 +1,97 @@
+package assert
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type Contact struct {
+	Email string
+	Phone string
+}
+
+func (c *Contact) AssertSelf(ctx context.Context) []Violation {
+	if c.Email == "" && c.Phone == "" {
+		return []Violation{{Constraint: "contact", Message: "at least one contact method is required"}}
+	}
+	return nil
+}
+
+type Customer struct {
+	Name     string `assert:"required=true"`
+	Contacts []Contact
+	Website  url.URL
+}
+
+func init() {
+	RegisterAsserter(url.URL{}, func(ctx context.Context, v interface{}) []Violation {
+		if u := v.(url.URL); u.Scheme != "https" {
+			return []Violation{{Field: "Scheme", Constraint: "https"}}
+		}
+		return nil
+	})
+}
+
+func TestAssertSelf(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer Customer
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			customer: Customer{
+				Name:     "Kirk",
+				Contacts: []Contact{{Email: "kirk@example.com"}},
+				Website:  url.URL{Scheme: "https", Host: "example.com"},
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			customer: Customer{
+				Contacts: []Contact{{Email: "kirk@example.com"}, {}},
+				Website:  url.URL{Scheme: "http", Host: "example.com"},
+			},
+			expected: &[]Violation{
+				{Field: "Customer.Name", Constraint: "required"},
+				{Field: "Customer.Contact", Constraint: "contact", Message: "at least one contact method is required"},
+				{Field: "Customer.URL.Scheme", Constraint: "https"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := AssertContext(context.Background(), tt.customer); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("AssertContext() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+// Ledger records the struct its AssertSelf rule is called on.
+type Ledger struct {
+	Balance int
+}
+
+var assertedLedger *Ledger
+
+func (l *Ledger) AssertSelf(ctx context.Context) []Violation {
+	assertedLedger = l
+	return nil
+}
+
+func TestAssertSelfAddressable(t *testing.T) {
+	ledger := Ledger{Balance: 1701}
+
+	if violations := Assert(&ledger); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	if assertedLedger != &ledger {
+		t.Errorf("Assert() asserted %p, expected the struct passed %p", assertedLedger, &ledger)
+	}
+}
@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The charsets map contains the character classes used with the charset assertion as values each associated with
+// the class name as the key.
+var charsets = map[string]func(r rune) bool{
+	"alpha":        unicode.IsLetter,
+	"digit":        unicode.IsDigit,
+	"alphanumeric": func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) },
+	"space":        unicode.IsSpace,
+	"ascii":        func(r rune) bool { return r <= unicode.MaxASCII },
+	"printable":    unicode.IsPrint,
+	"nocontrol":    func(r rune) bool { return !unicode.IsControl(r) },
+	"lower":        func(r rune) bool { return !unicode.IsUpper(r) && !unicode.IsTitle(r) },
+	"upper":        func(r rune) bool { return !unicode.IsLower(r) && !unicode.IsTitle(r) },
+}
+
+// assertCharset checks that every character of the field value, a string, is in one of the character classes
+// listed, e.g. charset=alpha|space.
+func assertCharset(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if classes, ok := assertions["charset"]; ok {
+		preds := make([]func(r rune) bool, 0)
+
+		for _, class := range strings.Split(classes, "|") {
+			if pred, ok := charsets[class]; ok {
+				preds = append(preds, pred)
+			} else {
+				log.Printf("unknown character class %s used with charset validation", class)
+			}
+		}
+
+		violations = assertRunes("charset", func(r rune) bool { return anyPred(preds, r) }, val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertScript checks that every character of the field value, a string, is in one of the Unicode scripts listed,
+// e.g. script=Latin|Common.
+func assertScript(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if scripts, ok := assertions["script"]; ok {
+		violations = assertRunes("script", asRangeTables(scripts, unicode.Scripts, "script"), val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertCategory checks that every character of the field value, a string, is in one of the Unicode general
+// categories listed, e.g. category=L|Nd.
+func assertCategory(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if categories, ok := assertions["category"]; ok {
+		violations = assertRunes("category", asRangeTables(categories, unicode.Categories, "category"), val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertTrimmed checks that the field value, a string, has no leading or trailing white space.
+func assertTrimmed(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["trimmed"] == "true" {
+		value, ok := asStringValue(val)
+
+		if !ok {
+			return violations
+		}
+
+		if strings.TrimSpace(value) != value {
+			message := "must not have leading or trailing white space"
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "trimmed", Message: message}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// assertRunes checks that every character of the field value, a string, satisfies pred. The violation names the
+// first offending character and its position, counted in characters from zero.
+func assertRunes(constraint string, pred func(r rune) bool, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	value, ok := asStringValue(val)
+
+	if !ok {
+		return violations
+	}
+
+	position := 0
+
+	for i, r := range value {
+		var message string
+
+		if r == utf8.RuneError && !strings.HasPrefix(value[i:], string(utf8.RuneError)) {
+			message = fmt.Sprintf("invalid UTF-8 at position %d", position)
+		} else if !pred(r) {
+			message = fmt.Sprintf("invalid character %q (%U) at position %d", r, r, position)
+		}
+
+		if message != "" {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint, Message: message}
+			*violations = append(*violations, violation)
+			break
+		}
+
+		position++
+	}
+
+	return violations
+}
+
+// asRangeTables returns a predicate matching the characters in the named tables, e.g. the Latin|Common scripts.
+func asRangeTables(names string, tables map[string]*unicode.RangeTable, constraint string) func(r rune) bool {
+	in := make([]*unicode.RangeTable, 0)
+
+	for _, name := range strings.Split(names, "|") {
+		if table, ok := tables[name]; ok {
+			in = append(in, table)
+		} else {
+			log.Printf("unknown %s %s used with %s validation", constraint, name, constraint)
+		}
+	}
+
+	return func(r rune) bool { return unicode.IsOneOf(in, r) }
+}
+
+func anyPred(preds []func(r rune) bool, r rune) bool {
+	for _, pred := range preds {
+		if pred(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssertCharsets(t *testing.T) {
+	type Profile struct {
+		Username string `assert:"charset=alphanumeric"`
+		Name     string `assert:"charset=alpha|space,trimmed=true"`
+		Code     string `assert:"charset=ascii|upper"`
+		Nickname string `assert:"script=Latin|Common"`
+		Handle   string `assert:"category=Ll|Nd"`
+	}
+
+	tests := []struct {
+		name     string
+		profile  Profile
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			profile: Profile{
+				Username: "kirk1701",
+				Name:     "José Kirk",
+				Code:     "NCC-1701",
+				Nickname: "Jim Kirk!",
+				Handle:   "kirk1701",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			profile: Profile{
+				Username: "kirk_1701",
+				Name:     " Kirk",
+				Nickname: "Jim Кирк",
+				Handle:   "Kirk",
+			},
+			expected: &[]Violation{
+				{Field: "Profile.Username", Constraint: "charset", Message: `invalid character '_' (U+005F) at position 4`},
+				{Field: "Profile.Name", Constraint: "trimmed", Message: "must not have leading or trailing white space"},
+				{Field: "Profile.Nickname", Constraint: "script", Message: `invalid character 'К' (U+041A) at position 4`},
+				{Field: "Profile.Handle", Constraint: "category", Message: `invalid character 'K' (U+004B) at position 0`},
+			},
+		},
+		{
+			name: "scenario3",
+			profile: Profile{
+				Name: "Kirk \u200b",
+			},
+			expected: &[]Violation{
+				{Field: "Profile.Name", Constraint: "charset", Message: `invalid character '\u200b' (U+200B) at position 5`},
+			},
+		},
+		{
+			name: "scenario4",
+			profile: Profile{
+				Username: "kirk\xff",
+			},
+			expected: &[]Violation{
+				{Field: "Profile.Username", Constraint: "charset", Message: "invalid UTF-8 at position 4"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.profile); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+
+	if result := assertCharset(map[string]string{"charset": "lower"}, reflect.ValueOf("kirk-1701"), "Slug", &[]Violation{}, ""); len(*result) != 0 {
+		t.Errorf("assertCharset() = %+v, expected no violations", *result)
+	}
+}
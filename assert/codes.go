@@ -0,0 +1,164 @@
+package assert
+
+import (
+	_ "embed"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed data/subdivisions.tsv
+var subdivisionsTSV string
+
+//go:embed data/currencies.tsv
+var currenciesTSV string
+
+// The subdivisions map contains the names of the ISO 3166-2 subdivisions associated with their codes, e.g. US-TN.
+var subdivisions = loadCodes(subdivisionsTSV, 0, 1)
+
+// The currencies map contains the names of the ISO 4217 currencies associated with their alphabetic codes.
+var currencies = loadCodes(currenciesTSV, 0, 2)
+
+// The currencyNumbers map contains the names of the ISO 4217 currencies associated with their numeric codes.
+var currencyNumbers = loadCodes(currenciesTSV, 1, 2)
+
+// loadCodes parses an embedded table of codes into a map of the key column to the value column.
+func loadCodes(table string, key int, value int) map[string]string {
+	codes := make(map[string]string)
+
+	for _, columns := range readTSV(table) {
+		codes[columns[key]] = columns[value]
+	}
+
+	return codes
+}
+
+// assertCountry checks that the field value, a string, is an ISO 3166-1 country code of the type specified:
+// country=alpha2, country=alpha3, country=numeric or country=any.
+func assertCountry(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if kind, ok := assertions["country"]; ok {
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		c, found := countries[value]
+
+		switch kind {
+		case "alpha2":
+			found = found && value == c.alpha2
+		case "alpha3":
+			found = found && value == c.alpha3
+		case "numeric":
+			found = found && value == c.numeric
+		case "any":
+		default:
+			log.Printf("unknown country code type %s used with country validation", kind)
+			return violations
+		}
+
+		if !found {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "country", Message: "must be an ISO 3166-1 " + kind + " country code"}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// assertSubdivision checks that the field value, a string, is an ISO 3166-2 subdivision code. With subdivision=true
+// the value is a full code, e.g. US-TN. Otherwise the assertion references the field holding the country, e.g.
+// subdivision=Country, and the value is either a full code of that country or the part after the hyphen, e.g. TN.
+func assertSubdivision(assertions map[string]string, f field, s *scope) {
+	value, ok := asStringValue(f.val)
+
+	if !ok || value == "" {
+		return
+	}
+
+	ref := assertions["subdivision"]
+
+	if ref == "true" {
+		if _, ok := subdivisions[value]; !ok {
+			s.reportMessage(f, "subdivision", "must be an ISO 3166-2 subdivision code")
+		}
+		return
+	}
+
+	v, found := s.lookup(ref)
+
+	if !found {
+		log.Printf("unknown field %s referenced by subdivision validation", ref)
+		return
+	}
+
+	code, _ := asStringValue(v)
+	c, known := lookupCountry(code)
+
+	// an unknown country is left to the country assertion
+	if !known {
+		return
+	}
+
+	full := value
+	if !strings.HasPrefix(value, c.alpha2+"-") {
+		full = c.alpha2 + "-" + value
+	}
+
+	if _, ok := subdivisions[full]; !ok {
+		s.reportMessage(f, "subdivision", fmt.Sprintf("must be an ISO 3166-2 subdivision of %s", c.name))
+	}
+}
+
+// assertCurrency checks that the field value, a string, is an ISO 4217 currency code: currency=alpha for the
+// alphabetic code, e.g. USD, or currency=numeric for the numeric code, e.g. 840.
+func assertCurrency(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if kind, ok := assertions["currency"]; ok {
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		codes := currencies
+
+		switch kind {
+		case "alpha":
+		case "numeric":
+			codes = currencyNumbers
+		default:
+			log.Printf("unknown currency code type %s used with currency validation", kind)
+			return violations
+		}
+
+		if _, ok := codes[value]; !ok {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "currency", Message: "must be an ISO 4217 " + kind + " currency code"}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// assertLanguage checks, when language=true, that the field value, a string, is a well-formed BCP 47 language tag,
+// e.g. en-US. The violation's message holds the reason the tag was rejected.
+func assertLanguage(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["language"] == "true" {
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		if _, err := language.Parse(value); err != nil {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "language", Message: err.Error()}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
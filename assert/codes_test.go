@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertCodes(t *testing.T) {
+	type Office struct {
+		Country  string `assert:"country=alpha2"`
+		Region   string `assert:"subdivision=Country"`
+		Area     string `assert:"subdivision=true"`
+		Origin   string `assert:"country=numeric"`
+		Currency string `assert:"currency=alpha"`
+		Locale   string `assert:"language=true"`
+	}
+
+	tests := []struct {
+		name     string
+		office   Office
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			office: Office{
+				Country:  "US",
+				Region:   "TN",
+				Area:     "CA-QC",
+				Origin:   "840",
+				Currency: "USD",
+				Locale:   "en-US",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			office:   Office{Country: "CA", Region: "CA-QC"},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario3",
+			office: Office{
+				Country:  "USA",
+				Region:   "TN",
+				Area:     "QC",
+				Origin:   "US",
+				Currency: "usd",
+				Locale:   "en-US-x",
+			},
+			expected: &[]Violation{
+				{Field: "Office.Country", Constraint: "country", Message: "must be an ISO 3166-1 alpha2 country code"},
+				{Field: "Office.Area", Constraint: "subdivision", Message: "must be an ISO 3166-2 subdivision code"},
+				{Field: "Office.Origin", Constraint: "country", Message: "must be an ISO 3166-1 numeric country code"},
+				{Field: "Office.Currency", Constraint: "currency", Message: "must be an ISO 4217 alpha currency code"},
+				{Field: "Office.Locale", Constraint: "language", Message: "language: tag is not well-formed"},
+			},
+		},
+		{
+			name:   "scenario4",
+			office: Office{Country: "CA", Region: "TN"},
+			expected: &[]Violation{
+				{Field: "Office.Region", Constraint: "subdivision", Message: "must be an ISO 3166-2 subdivision of Canada"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.office); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertCodesAlpha3(t *testing.T) {
+	type Mailing struct {
+		State    string `assert:"subdivision=Country"`
+		Country  string `assert:"country=alpha3"`
+		Currency string `assert:"currency=alpha"`
+	}
+
+	if violations := Assert(Mailing{State: "TN", Country: "USA", Currency: "ZWG"}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{
+		{Field: "Mailing.Country", Constraint: "country", Message: "must be an ISO 3166-1 alpha3 country code"},
+		{Field: "Mailing.Currency", Constraint: "currency", Message: "must be an ISO 4217 alpha currency code"},
+	}
+
+	if violations := Assert(Mailing{State: "TN", Country: "US", Currency: "HRK"}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
@@ -0,0 +1,136 @@
+package assert
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// assertRequiredIf checks that the field is set when the referenced field has one of the values specified, e.g.
+// required_if=Method card or required_if=Country US|CA. The violation names the value the referenced field has.
+func assertRequiredIf(assertions map[string]string, f field, s *scope) {
+	if ref, actual, matched := s.matchesValue(assertions, "required_if"); matched && isAbsent(f, s) {
+		s.reportMessage(f, "required_if", fmt.Sprintf("required when %s is %s", ref, actual))
+	}
+}
+
+// assertRequiredUnless checks that the field is set unless the referenced field has one of the values specified.
+func assertRequiredUnless(assertions map[string]string, f field, s *scope) {
+	if ref, actual, matched := s.matchesValue(assertions, "required_unless"); ref != "" && !matched && isAbsent(f, s) {
+		s.reportMessage(f, "required_unless", fmt.Sprintf("required when %s is %s", ref, actual))
+	}
+}
+
+// assertRequiredWith checks that the field is set when the referenced field is set.
+func assertRequiredWith(assertions map[string]string, f field, s *scope) {
+	if ref, set := s.isSet(assertions, "required_with"); set && isAbsent(f, s) {
+		s.reportMessage(f, "required_with", fmt.Sprintf("required when %s is present", ref))
+	}
+}
+
+// assertRequiredWithout checks that the field is set when the referenced field is not set.
+func assertRequiredWithout(assertions map[string]string, f field, s *scope) {
+	if ref, set := s.isSet(assertions, "required_without"); ref != "" && !set && isAbsent(f, s) {
+		s.reportMessage(f, "required_without", fmt.Sprintf("required when %s is absent", ref))
+	}
+}
+
+// assertExcludedIf checks that the field is not set when the referenced field has one of the values specified.
+func assertExcludedIf(assertions map[string]string, f field, s *scope) {
+	if ref, actual, matched := s.matchesValue(assertions, "excluded_if"); matched && !isAbsent(f, s) {
+		s.reportMessage(f, "excluded_if", fmt.Sprintf("not allowed when %s is %s", ref, actual))
+	}
+}
+
+// assertExcludedWith checks that the field is not set when the referenced field is set.
+func assertExcludedWith(assertions map[string]string, f field, s *scope) {
+	if ref, set := s.isSet(assertions, "excluded_with"); set && !isAbsent(f, s) {
+		s.reportMessage(f, "excluded_with", fmt.Sprintf("not allowed when %s is present", ref))
+	}
+}
+
+// matchesValue returns true if the field referenced by the constraint has one of the values listed after it. It also
+// returns the name of the referenced field, which is empty if the constraint is malformed, and its value, or empty
+// when the field is empty. The value of a password is never returned, only whether it is set.
+func (s *scope) matchesValue(assertions map[string]string, constraint string) (string, string, bool) {
+	ref, values, ok := strings.Cut(assertions[constraint], " ")
+
+	if !ok {
+		log.Printf("%s validation requires a field and a value", constraint)
+		return "", "", false
+	}
+
+	v, tag, found := s.lookupField(ref)
+
+	if !found {
+		log.Printf("unknown field %s referenced by %s validation", ref, constraint)
+		return "", "", false
+	}
+
+	actual := ""
+	if v.IsValid() {
+		actual = fmt.Sprint(v)
+	}
+
+	described := asDescribedValue(actual)
+
+	if _, ok := asAssertions(tag)["password"]; ok && actual != "" {
+		described = "set"
+	}
+
+	for _, value := range strings.Split(values, "|") {
+		if actual == value {
+			return ref, described, true
+		}
+	}
+
+	return ref, described, false
+}
+
+// asDescribedValue returns the value as named in a violation message.
+func asDescribedValue(value string) string {
+	if value == "" {
+		return "empty"
+	}
+	return value
+}
+
+// isSet returns true if the field referenced by the constraint is not empty. It also returns the name of the
+// referenced field, which is empty if the field doesn't exist.
+func (s *scope) isSet(assertions map[string]string, constraint string) (string, bool) {
+	ref := assertions[constraint]
+	v, found := s.lookup(ref)
+
+	if !found {
+		log.Printf("unknown field %s referenced by %s validation", ref, constraint)
+		return "", false
+	}
+
+	return ref, !isEmpty(v)
+}
+
+// isAbsent returns true if the field was absent from the decoded document or, when no document was decoded, if the
+// field value is empty.
+func isAbsent(f field, s *scope) bool {
+	if s.decoded {
+		return f.presence == nil || f.presence.null
+	}
+	return isEmpty(f.val)
+}
+
+// isEmpty returns true if v is a nil pointer, the zero value of its type or an empty string, slice or map.
+func isEmpty(v reflect.Value) bool {
+	v = indirect(v)
+
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0 || v.IsZero()
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Payment struct {
+	Method     string `assert:"required=true"`
+	CardNumber string `assert:"required_if=Method card,excluded_if=Method cash"`
+	Reference  string `assert:"required_unless=Method card|cash"`
+	Email      string `assert:"required_without=Phone"`
+	Phone      string
+	Extension  string `assert:"required_with=Phone"`
+	Fax        string `assert:"excluded_with=Email"`
+}
+
+func TestAssertConditional(t *testing.T) {
+	tests := []struct {
+		name     string
+		payment  Payment
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			payment:  Payment{Method: "card", CardNumber: "4111111111111111", Email: "kirk@example.com"},
+			expected: &[]Violation{},
+		},
+		{
+			name:    "scenario2",
+			payment: Payment{Method: "card", Phone: "555-0100", Extension: "12"},
+			expected: &[]Violation{
+				{Field: "Payment.CardNumber", Constraint: "required_if", Message: "required when Method is card"},
+			},
+		},
+		{
+			name:    "scenario3",
+			payment: Payment{Method: "cash", CardNumber: "4111111111111111"},
+			expected: &[]Violation{
+				{Field: "Payment.CardNumber", Constraint: "excluded_if", Message: "not allowed when Method is cash"},
+				{Field: "Payment.Email", Constraint: "required_without", Message: "required when Phone is absent"},
+			},
+		},
+		{
+			name:    "scenario4",
+			payment: Payment{Method: "wire", Email: "kirk@example.com", Phone: "555-0100", Fax: "555-0101"},
+			expected: &[]Violation{
+				{Field: "Payment.Reference", Constraint: "required_unless", Message: "required when Method is wire"},
+				{Field: "Payment.Extension", Constraint: "required_with", Message: "required when Phone is present"},
+				{Field: "Payment.Fax", Constraint: "excluded_with", Message: "not allowed when Email is present"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.payment); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertConditionalValue(t *testing.T) {
+	type Shipment struct {
+		Carrier   string
+		Tracking  string `assert:"required_if=Carrier ups|fedex"`
+		Signature string `assert:"required_unless=Carrier ups|fedex"`
+	}
+
+	expected := []Violation{{Field: "Shipment.Tracking", Constraint: "required_if", Message: "required when Carrier is fedex"}}
+
+	if violations := Assert(Shipment{Carrier: "fedex"}); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+
+	expected = []Violation{{Field: "Shipment.Signature", Constraint: "required_unless", Message: "required when Carrier is empty"}}
+
+	if violations := Assert(Shipment{}); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
+
+func TestAssertConditionalPassword(t *testing.T) {
+	type Signup struct {
+		Password string `assert:"password=default"`
+		Hint     string `assert:"required_unless=Password x"`
+		Token    string `assert:"excluded_if=Password ncc1701enterprise"`
+	}
+
+	expected := []Violation{
+		{Field: "Signup.Hint", Constraint: "required_unless", Message: "required when Password is set"},
+		{Field: "Signup.Token", Constraint: "excluded_if", Message: "not allowed when Password is set"},
+	}
+
+	if violations := Assert(Signup{Password: "ncc1701enterprise", Token: "abc"}); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
@@ -0,0 +1,356 @@
+package assert
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/confusables.tsv
+var confusablesTSV string
+
+// The prototypes map contains the prototype of each confusable character, the string it can be mistaken for, from
+// the UTS #39 confusables data, e.g. the Latin a for the Cyrillic а.
+var prototypes = loadPrototypes(confusablesTSV)
+
+// The normalForms map contains the Unicode normalization forms used with the normalized assertion.
+var normalForms = map[string]norm.Form{
+	"NFC":  norm.NFC,
+	"NFD":  norm.NFD,
+	"NFKC": norm.NFKC,
+	"NFKD": norm.NFKD,
+}
+
+// The restrictionLevels map contains the UTS #39 restriction levels used with the restriction assertion as values
+// each associated with the level name as the key. A level accepts the set of scripts used by a value, which excludes
+// the Common and Inherited scripts.
+var restrictionLevels = map[string]func(scripts []string, value string) bool{
+	"ascii":    isASCIIOnly,
+	"single":   isSingleScript,
+	"highly":   isHighlyRestrictive,
+	"moderate": isModeratelyRestrictive,
+}
+
+// The highlyRestrictiveSets slice contains the sets of scripts, other than single scripts, that are allowed by the
+// highly restrictive level.
+var highlyRestrictiveSets = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// loadPrototypes parses the embedded table of confusable characters, skipping blank lines and comments.
+func loadPrototypes(table string) map[rune]string {
+	loaded := make(map[rune]string)
+	scanner := bufio.NewScanner(strings.NewReader(table))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		columns := strings.Split(line, "\t")
+		source, err := strconv.ParseUint(columns[0], 16, 32)
+
+		if err != nil {
+			log.Printf("%s:%+v", "unable to parse confusable character", err)
+			continue
+		}
+
+		prototype := strings.Builder{}
+
+		for _, cp := range strings.Fields(columns[1]) {
+			r, _ := strconv.ParseUint(cp, 16, 32)
+			prototype.WriteRune(rune(r))
+		}
+
+		loaded[rune(source)] = prototype.String()
+	}
+
+	return loaded
+}
+
+// Skeleton returns the UTS #39 skeleton of s. Strings that can be mistaken for each other have the same skeleton,
+// e.g. paypal and раураl spelled with Cyrillic letters, so a new username can be checked against the existing ones
+// by comparing their skeletons.
+func Skeleton(s string) string {
+	skeleton := strings.Builder{}
+
+	for _, r := range norm.NFD.String(s) {
+		if prototype, ok := prototypes[r]; ok {
+			skeleton.WriteString(prototype)
+		} else {
+			skeleton.WriteRune(r)
+		}
+	}
+
+	return norm.NFD.String(skeleton.String())
+}
+
+// assertConfusable checks, when confusable=false, that the field value, a string, can't be mistaken for a different
+// ASCII string, such as раураl spelled with Cyrillic letters or ｐａｙｐａｌ with fullwidth ones. The violation's
+// code is confusable and its message names the ASCII string.
+func assertConfusable(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["confusable"] == "false" {
+		value, ok := asStringValue(val)
+
+		if !ok || isASCIIOnly(nil, value) {
+			return violations
+		}
+
+		if lookalike, ok := asASCIILookalike(value); ok {
+			violation := Violation{
+				Field:      asQualifiedPath(path, name),
+				Constraint: "confusable",
+				Code:       "confusable",
+				Message:    fmt.Sprintf("can be mistaken for %q", lookalike),
+			}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// asASCIILookalike returns the ASCII string that value can be mistaken for, replacing each character that isn't ASCII
+// with its prototype once compatibility characters, such as fullwidth letters, are decomposed. It returns false if a
+// character has no ASCII prototype.
+func asASCIILookalike(value string) (string, bool) {
+	lookalike := strings.Builder{}
+
+	for _, r := range norm.NFKD.String(value) {
+		if r <= unicode.MaxASCII {
+			lookalike.WriteRune(r)
+			continue
+		}
+
+		prototype, ok := prototypes[r]
+
+		if !ok || !isASCIIOnly(nil, prototype) {
+			return "", false
+		}
+
+		lookalike.WriteString(prototype)
+	}
+
+	return lookalike.String(), true
+}
+
+// assertNormalized checks that the field value, a string, is in the Unicode normalization form specified, e.g.
+// normalized=NFC. The violation's code is not_ followed by the form in lower case, e.g. not_nfc.
+func assertNormalized(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if form, ok := assertions["normalized"]; ok {
+		f, ok := normalForms[form]
+
+		if !ok {
+			log.Printf("unknown normalization form %s used with normalized validation", form)
+			return violations
+		}
+
+		if value, ok := asStringValue(val); ok && !f.IsNormalString(value) {
+			violation := Violation{
+				Field:      asQualifiedPath(path, name),
+				Constraint: "normalized",
+				Code:       "not_" + strings.ToLower(form),
+				Message:    "must be in Unicode normalization form " + form,
+			}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// assertInvisible checks, when invisible=false, that the field value, a string, has no invisible characters such as
+// zero-width spaces and joiners, soft hyphens or variation selectors. The violation's code is invisible_character.
+func assertInvisible(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["invisible"] == "false" {
+		violations = assertNoRune("invisible", "invisible_character", isInvisible, val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertBidi checks, when bidi=false, that the field value, a string, has no bidirectional control characters such as
+// the right-to-left override. The violation's code is bidi_control.
+func assertBidi(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["bidi"] == "false" {
+		violations = assertNoRune("bidi", "bidi_control", func(r rune) bool { return unicode.Is(unicode.Bidi_Control, r) }, val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertRestriction checks that the scripts mixed in the field value, a string, are allowed by the UTS #39
+// restriction level specified, e.g. restriction=highly. The levels supported are ascii, single, highly and moderate.
+// The violation's code is mixed_script. Scripts are determined from the Unicode Script property.
+func assertRestriction(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if level, ok := assertions["restriction"]; ok {
+		allowed, ok := restrictionLevels[level]
+
+		if !ok {
+			log.Printf("unknown restriction level %s used with restriction validation", level)
+			return violations
+		}
+
+		value, ok := asStringValue(val)
+
+		if !ok {
+			return violations
+		}
+
+		if scripts := asScripts(value); !allowed(scripts, value) {
+			violation := Violation{
+				Field:      asQualifiedPath(path, name),
+				Constraint: "restriction",
+				Code:       "mixed_script",
+				Message:    "mixes the " + strings.Join(scripts, ", ") + " scripts",
+			}
+
+			if level == "ascii" {
+				violation.Message = "must only have ASCII characters"
+			}
+
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// assertNoRune checks that no character of the field value, a string, satisfies pred. The violation names the first
+// offending character and its position, counted in characters from zero.
+func assertNoRune(constraint string, code string, pred func(r rune) bool, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	value, ok := asStringValue(val)
+
+	if !ok {
+		return violations
+	}
+
+	position := 0
+
+	for _, r := range value {
+		if pred(r) {
+			violation := Violation{
+				Field:      asQualifiedPath(path, name),
+				Constraint: constraint,
+				Code:       code,
+				Message:    fmt.Sprintf("invalid character %q (%U) at position %d", r, r, position),
+			}
+			*violations = append(*violations, violation)
+			break
+		}
+
+		position++
+	}
+
+	return violations
+}
+
+// isInvisible returns true if r is a default ignorable character, which is rendered invisibly. Bidirectional controls
+// are left to the bidi assertion.
+func isInvisible(r rune) bool {
+	if unicode.Is(unicode.Bidi_Control, r) || unicode.Is(unicode.Prepended_Concatenation_Mark, r) {
+		return false
+	}
+
+	return unicode.In(r, unicode.Cf, unicode.Other_Default_Ignorable_Code_Point, unicode.Variation_Selector)
+}
+
+// asScripts returns the sorted names of the scripts used by value, other than Common and Inherited.
+func asScripts(value string) []string {
+	seen := make(map[string]bool)
+
+	for _, r := range value {
+		if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+
+		for script, table := range unicode.Scripts {
+			if unicode.Is(table, r) {
+				seen[script] = true
+				break
+			}
+		}
+	}
+
+	scripts := make([]string, 0, len(seen))
+
+	for script := range seen {
+		scripts = append(scripts, script)
+	}
+
+	sort.Strings(scripts)
+
+	return scripts
+}
+
+func isASCIIOnly(scripts []string, value string) bool {
+	for _, r := range value {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func isSingleScript(scripts []string, value string) bool {
+	return len(scripts) <= 1
+}
+
+func isHighlyRestrictive(scripts []string, value string) bool {
+	if isSingleScript(scripts, value) {
+		return true
+	}
+
+	for _, set := range highlyRestrictiveSets {
+		if isSubset(scripts, set) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isModeratelyRestrictive(scripts []string, value string) bool {
+	if isHighlyRestrictive(scripts, value) {
+		return true
+	}
+
+	// Latin can be mixed with any one other script except Cyrillic and Greek
+	if len(scripts) != 2 || !isSubset([]string{"Latin"}, scripts) {
+		return false
+	}
+
+	return !isSubset([]string{"Cyrillic"}, scripts) && !isSubset([]string{"Greek"}, scripts)
+}
+
+// isSubset returns true if every element of a is in b.
+func isSubset(a []string, b []string) bool {
+	for _, x := range a {
+		found := false
+
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertConfusables(t *testing.T) {
+	type Account struct {
+		Username    string `assert:"normalized=NFKC,invisible=false,bidi=false,restriction=single"`
+		DisplayName string `assert:"normalized=NFC,restriction=highly"`
+		Handle      string `assert:"restriction=moderate"`
+		Login       string `assert:"restriction=ascii"`
+	}
+
+	tests := []struct {
+		name     string
+		account  Account
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			account: Account{
+				Username:    "kirk_1701",
+				DisplayName: "José 山田たろう",
+				Handle:      "kirkש",
+				Login:       "kirk",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			account: Account{
+				Username:    "ki\u200brk",
+				DisplayName: "Jose\u0301",
+				Handle:      "kirkа",
+				Login:       "kïrk",
+			},
+			expected: &[]Violation{
+				{Field: "Account.Username", Constraint: "invisible", Code: "invisible_character", Message: `invalid character '\u200b' (U+200B) at position 2`},
+				{Field: "Account.DisplayName", Constraint: "normalized", Code: "not_nfc", Message: "must be in Unicode normalization form NFC"},
+				{Field: "Account.Handle", Constraint: "restriction", Code: "mixed_script", Message: "mixes the Cyrillic, Latin scripts"},
+				{Field: "Account.Login", Constraint: "restriction", Code: "mixed_script", Message: "must only have ASCII characters"},
+			},
+		},
+		{
+			name: "scenario3",
+			account: Account{
+				Username: "\ufb01rk\u202eр",
+			},
+			expected: &[]Violation{
+				{Field: "Account.Username", Constraint: "normalized", Code: "not_nfkc", Message: "must be in Unicode normalization form NFKC"},
+				{Field: "Account.Username", Constraint: "bidi", Code: "bidi_control", Message: `invalid character '\u202e' (U+202E) at position 3`},
+				{Field: "Account.Username", Constraint: "restriction", Code: "mixed_script", Message: "mixes the Cyrillic, Latin scripts"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.account); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertConfusable(t *testing.T) {
+	type Account struct {
+		Username string `assert:"confusable=false"`
+	}
+
+	tests := []struct {
+		name     string
+		account  Account
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			account:  Account{Username: "paypal"},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			account:  Account{Username: "Соня"},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario3",
+			account:  Account{Username: "café"},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario4",
+			account:  Account{Username: "раураl"},
+			expected: &[]Violation{{Field: "Account.Username", Constraint: "confusable", Code: "confusable", Message: `can be mistaken for "paypal"`}},
+		},
+		{
+			name:     "scenario5",
+			account:  Account{Username: "ｋｉｒｋ"},
+			expected: &[]Violation{{Field: "Account.Username", Constraint: "confusable", Code: "confusable", Message: `can be mistaken for "kirk"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.account); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSkeleton(t *testing.T) {
+	if Skeleton("раураl") != Skeleton("paypal") {
+		t.Errorf("Skeleton() = %q, expected %q", Skeleton("раураl"), Skeleton("paypal"))
+	}
+
+	if Skeleton("kirk") == Skeleton("kink") {
+		t.Errorf("Skeleton() = %q, expected a different skeleton than %q", Skeleton("kirk"), Skeleton("kink"))
+	}
+}
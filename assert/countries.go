@@ -0,0 +1,79 @@
+package assert
+
+import (
+	"bufio"
+	_ "embed"
+	"regexp"
+	"strings"
+)
+
+//go:embed data/countries.tsv
+var countriesTSV string
+
+// country is an ISO 3166-1 country. postal is nil for countries without postal codes or with an unknown postal
+// code format, which are told apart by hasPostal. callingCode is empty for countries without a calling code.
+type country struct {
+	alpha2      string
+	alpha3      string
+	numeric     string
+	name        string
+	postal      *regexp.Regexp
+	hasPostal   bool
+	callingCode string
+}
+
+// The countries map contains the ISO 3166-1 countries associated with each of their alpha-2, alpha-3 and numeric
+// codes as the keys.
+var countries = loadCountries(countriesTSV)
+
+// loadCountries parses the embedded table of countries.
+func loadCountries(table string) map[string]*country {
+	loaded := make(map[string]*country)
+
+	for _, columns := range readTSV(table) {
+		c := &country{alpha2: columns[0], alpha3: columns[1], numeric: columns[2], name: columns[5]}
+
+		if columns[4] != "-" {
+			c.callingCode = columns[4]
+		}
+
+		switch columns[3] {
+		case "-":
+		case "":
+			c.hasPostal = true
+		default:
+			c.hasPostal = true
+			c.postal = regexp.MustCompile(`^(?i:` + columns[3] + `)$`)
+		}
+
+		loaded[c.alpha2] = c
+		loaded[c.alpha3] = c
+		loaded[c.numeric] = c
+	}
+
+	return loaded
+}
+
+// readTSV returns the columns of each line of the tab-separated table, skipping blank lines and comments.
+func readTSV(table string) [][]string {
+	rows := make([][]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(table))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+
+	return rows
+}
+
+// lookupCountry returns the country with the alpha-2, alpha-3 or numeric code given in any case.
+func lookupCountry(code string) (*country, bool) {
+	c, ok := countries[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
@@ -0,0 +1,183 @@
+package assert
+
+import (
+	"cmp"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// assertCompareField returns a validation function that compares the field value with the value of the field
+// referenced by the constraint. The comparison passes when ok returns true for the result of the comparison, which
+// is negative, zero or positive as the field value is less than, equal to or greater than the referenced value.
+func assertCompareField(constraint string, ok func(c int) bool) func(assertions map[string]string, f field, s *scope) {
+	return func(assertions map[string]string, f field, s *scope) {
+		ref, found := s.lookup(assertions[constraint])
+
+		if !found {
+			log.Printf("unknown field %s referenced by %s validation", assertions[constraint], constraint)
+			return
+		}
+
+		val := indirect(f.val)
+
+		// nil pointers are left to the required assertion
+		if !val.IsValid() || !ref.IsValid() {
+			return
+		}
+
+		c, comparable := compareValues(val, ref)
+
+		// values without an order can still be tested for equality
+		if !comparable && (constraint == "eqfield" || constraint == "nefield") && val.Type() == ref.Type() {
+			c, comparable = 1, val.Comparable()
+
+			if comparable && val.Equal(ref) {
+				c = 0
+			}
+		}
+
+		if !comparable {
+			log.Printf("invalid field type used with %s validation", constraint)
+			return
+		}
+
+		if !ok(c) {
+			s.report(f, constraint)
+		}
+	}
+}
+
+// lookup returns the value of the field referenced by ref relative to the struct containing the field being asserted.
+// Fields of the sibling structs are referenced with a dotted path, e.g. Range.Start, and fields of the enclosing
+// structs are referenced by prefixing ../ for each level, e.g. ../MinPrice.
+func (s *scope) lookup(ref string) (reflect.Value, bool) {
+	v, _, found := s.lookupField(ref)
+	return v, found
+}
+
+// lookupField returns the value of the field referenced by ref like lookup, along with its struct tag.
+func (s *scope) lookupField(ref string) (reflect.Value, reflect.StructTag, bool) {
+	level := len(s.parents) - 1
+
+	for strings.HasPrefix(ref, "../") {
+		ref = strings.TrimPrefix(ref, "../")
+		level--
+	}
+
+	if level < 0 || ref == "" {
+		return reflect.Value{}, "", false
+	}
+
+	v := s.parents[level]
+	tag := reflect.StructTag("")
+
+	for _, name := range strings.Split(ref, ".") {
+		v = indirect(v)
+
+		if !v.IsValid() {
+			// a nil pointer along the path has no value to compare against
+			return v, "", true
+		}
+
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, "", false
+		}
+
+		sf, ok := v.Type().FieldByName(name)
+
+		if !ok {
+			return reflect.Value{}, "", false
+		}
+
+		v, tag = v.FieldByIndex(sf.Index), sf.Tag
+	}
+
+	return indirect(v), tag, true
+}
+
+// indirect returns the value that v points to, following any number of pointers and optional wrappers such as
+// sql.NullString. The zero Value is returned for a nil pointer or an unset wrapper.
+func indirect(v reflect.Value) reflect.Value {
+	for {
+		if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+			continue
+		}
+
+		w, ok := unwrap(v)
+
+		if !ok {
+			return v
+		}
+
+		v = w
+	}
+}
+
+// compareValues compares numbers, strings and time.Time values. It returns false if the values have no order.
+func compareValues(a reflect.Value, b reflect.Value) (int, bool) {
+	if at, ok := asTime(a); ok {
+		if bt, ok := asTime(b); ok {
+			return at.Compare(bt), true
+		}
+		return 0, false
+	}
+
+	switch {
+	case isInt(a) && isInt(b):
+		return cmp.Compare(a.Int(), b.Int()), true
+	case isUint(a) && isUint(b):
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case isNumber(a) && isNumber(b):
+		return cmp.Compare(asFloat(a), asFloat(b)), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	default:
+		return 0, false
+	}
+}
+
+// asTime returns the time.Time held by v.
+func asTime(v reflect.Value) (time.Time, bool) {
+	if v.Type() == timeType && v.CanInterface() {
+		return v.Interface().(time.Time), true
+	}
+	return time.Time{}, false
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// asFloat returns the value of the number v as a float64.
+func asFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
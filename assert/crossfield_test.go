@@ -0,0 +1,89 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Booking struct {
+	StartDate time.Time `assert:"required=true"`
+	EndDate   time.Time `assert:"gtfield=StartDate"`
+	Password  string
+	Confirm   string   `assert:"eqfield=Password"`
+	MinPrice  float64  `assert:"ltefield=MaxPrice"`
+	MaxPrice  int      `assert:"nefield=Guests.Count"`
+	Guests    *Guests  `assert:"required=true"`
+	Discount  *float64 `assert:"ltfield=MinPrice"`
+}
+
+type Guests struct {
+	Count int `assert:"ltefield=../MaxPrice"`
+}
+
+func TestAssertCompareField(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	discount := 25.0
+
+	tests := []struct {
+		name     string
+		booking  Booking
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			booking: Booking{
+				StartDate: start,
+				EndDate:   start.Add(time.Hour),
+				Password:  "secret",
+				Confirm:   "secret",
+				MinPrice:  10,
+				MaxPrice:  20,
+				Guests:    &Guests{Count: 2},
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			booking: Booking{
+				StartDate: start,
+				EndDate:   start,
+				Password:  "secret",
+				Confirm:   "secrets",
+				MinPrice:  30,
+				MaxPrice:  20,
+				Guests:    &Guests{Count: 20},
+				Discount:  &discount,
+			},
+			expected: &[]Violation{
+				{Field: "Booking.EndDate", Constraint: "gtfield"},
+				{Field: "Booking.Confirm", Constraint: "eqfield"},
+				{Field: "Booking.MinPrice", Constraint: "ltefield"},
+				{Field: "Booking.MaxPrice", Constraint: "nefield"},
+			},
+		},
+		{
+			name: "scenario3",
+			booking: Booking{
+				StartDate: start,
+				EndDate:   start.Add(time.Hour),
+				MinPrice:  10,
+				MaxPrice:  20,
+				Guests:    &Guests{Count: 21},
+				Discount:  &discount,
+			},
+			expected: &[]Violation{
+				{Field: "Booking.Guests.Count", Constraint: "ltefield"},
+				{Field: "Booking.Discount", Constraint: "ltfield"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.booking); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// assertLayout checks that the field value, a string, is a time in the Go layout specified, e.g. layout=2006-01-02.
+func assertLayout(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if layout, ok := assertions["layout"]; ok {
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		if _, err := time.Parse(layout, value); err != nil {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "layout", Message: err.Error()}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// assertAfter checks that the field value, a time.Time or a time string, is after the time specified, e.g.
+// after=2000-01-01 or after=now-24h.
+func assertAfter(assertions map[string]string, f field, s *scope) {
+	s.assertTimeBound(assertions, f, "after", assertions["after"], "must be after "+assertions["after"], time.Time.After)
+}
+
+// assertBefore checks that the field value, a time.Time or a time string, is before the time specified, e.g.
+// before=now+24h.
+func assertBefore(assertions map[string]string, f field, s *scope) {
+	s.assertTimeBound(assertions, f, "before", assertions["before"], "must be before "+assertions["before"], time.Time.Before)
+}
+
+// assertPast checks that the field value, a time.Time or a time string, is before now.
+func assertPast(assertions map[string]string, f field, s *scope) {
+	if assertions["past"] == "true" {
+		s.assertTimeBound(assertions, f, "past", "now", "must be in the past", time.Time.Before)
+	}
+}
+
+// assertFuture checks that the field value, a time.Time or a time string, is after now.
+func assertFuture(assertions map[string]string, f field, s *scope) {
+	if assertions["future"] == "true" {
+		s.assertTimeBound(assertions, f, "future", "now", "must be in the future", time.Time.After)
+	}
+}
+
+// assertTimeBound checks that the field value and the time bound satisfy ok, reporting message otherwise.
+func (s *scope) assertTimeBound(assertions map[string]string, f field, constraint string, bound string, message string, ok func(t time.Time, bound time.Time) bool) {
+	b, err := parseTimeBound(bound, s.now())
+
+	if err != nil {
+		log.Printf("%s:%+v", "unable to parse "+constraint+" tag value", err)
+		return
+	}
+
+	t, set, err := asTimeValue(f.val, assertions)
+
+	if !set {
+		return
+	}
+
+	if err != nil {
+		// an unparsable value is reported once, by the layout or format assertion when the field has one
+		if _, ok := assertions["layout"]; !ok && assertions["format"] == "" {
+			s.reportMessage(f, constraint, err.Error())
+		}
+		return
+	}
+
+	if !ok(t, b) {
+		s.reportMessage(f, constraint, message)
+	}
+}
+
+// assertDuration checks that the field value, a time.Duration, is within the min or max bound specified in the Go
+// duration syntax, e.g. max=30s.
+func assertDuration(assertions map[string]string, constraint string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	bound, err := time.ParseDuration(assertions[constraint])
+
+	if err != nil {
+		log.Printf("%s:%+v", "unable to parse "+constraint+" tag value", err)
+		return violations
+	}
+
+	d := time.Duration(val.Int())
+
+	if constraint == "min" && d < bound || constraint == "max" && d > bound {
+		violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint}
+		*violations = append(*violations, violation)
+	}
+
+	return violations
+}
+
+// now returns the current time used by the relative time constraints. The time set on the context with WithNow takes
+// precedence over the validator's clock.
+func (s *scope) now() time.Time {
+	if now, ok := s.ctx.Value(nowKey{}).(time.Time); ok {
+		return now
+	}
+	return s.clock.Now()
+}
+
+// asTimeValue returns the time held by val, which is a time.Time or a string parsed with the layout or format
+// assertion of the field, or else as RFC 3339 or a date. It returns false if val is a nil pointer, a zero time or an
+// empty string.
+func asTimeValue(val reflect.Value, assertions map[string]string) (time.Time, bool, error) {
+	val = indirect(val)
+
+	if !val.IsValid() {
+		return time.Time{}, false, nil
+	}
+
+	if t, ok := asTime(val); ok {
+		return t, !t.IsZero(), nil
+	}
+
+	value, ok := asStringValue(val)
+
+	if !ok || value == "" {
+		return time.Time{}, false, nil
+	}
+
+	if layout, ok := assertions["layout"]; ok {
+		t, err := time.Parse(layout, value)
+		return t, true, err
+	}
+
+	switch assertions["format"] {
+	case "rfc3339":
+		t, err := time.Parse(time.RFC3339, value)
+		return t, true, err
+	case "date":
+		t, err := time.Parse(time.DateOnly, value)
+		return t, true, err
+	}
+
+	t, err := parseTime(value)
+	return t, true, err
+}
+
+// parseTime parses an RFC 3339 time or a date.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// parseTimeBound parses the time a value is compared against. The time is an RFC 3339 time, a date, or now optionally
+// followed by a Go or ISO 8601 duration, e.g. now+24h or now-P1Y.
+func parseTimeBound(bound string, now time.Time) (time.Time, error) {
+	offset, relative := strings.CutPrefix(bound, "now")
+
+	if !relative {
+		return parseTime(bound)
+	}
+
+	if offset == "" {
+		return now, nil
+	}
+
+	sign := 1
+
+	switch offset[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return time.Time{}, fmt.Errorf("invalid offset %q", offset)
+	}
+
+	if d, err := time.ParseDuration(offset[1:]); err == nil {
+		return now.Add(time.Duration(sign) * d), nil
+	}
+
+	d, err := parseISODuration(offset[1:])
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return d.addTo(now, sign), nil
+}
+
+// isoDuration is an ISO 8601 duration. The years, months and days are kept apart from the time because their length
+// depends on the date they're added to.
+type isoDuration struct {
+	years, months, days int
+	time                time.Duration
+}
+
+// addTo returns t plus the duration multiplied by sign.
+func (d isoDuration) addTo(t time.Time, sign int) time.Time {
+	return t.AddDate(sign*d.years, sign*d.months, sign*d.days).Add(time.Duration(sign) * d.time)
+}
+
+// parseDuration accepts an ISO 8601 duration, e.g. P1Y2M3DT4H5M6S or P2W.
+func parseDuration(value string, assertions map[string]string) error {
+	_, err := parseISODuration(value)
+	return err
+}
+
+// parseISODuration parses an ISO 8601 duration in the PnYnMnDTnHnMnS or PnW format. Only the seconds can have a
+// fraction.
+func parseISODuration(value string) (isoDuration, error) {
+	d := isoDuration{}
+	rest, ok := strings.CutPrefix(value, "P")
+
+	if !ok || rest == "" || rest == "T" || strings.HasSuffix(rest, "T") {
+		return d, fmt.Errorf("duration: invalid ISO 8601 duration %q", value)
+	}
+
+	datePart, timePart, _ := strings.Cut(rest, "T")
+
+	if err := parseDurationPart(datePart, "YMWD", &d); err != nil {
+		return d, fmt.Errorf("duration: invalid ISO 8601 duration %q: %v", value, err)
+	}
+
+	if err := parseDurationPart(timePart, "HMS", &d); err != nil {
+		return d, fmt.Errorf("duration: invalid ISO 8601 duration %q: %v", value, err)
+	}
+
+	return d, nil
+}
+
+// parseDurationPart parses the date or time part of an ISO 8601 duration. The designators must appear in the order
+// given.
+func parseDurationPart(part string, designators string, d *isoDuration) error {
+	order := 0
+
+	for part != "" {
+		i := strings.IndexAny(part, designators)
+
+		if i <= 0 {
+			return errors.New("expected a number followed by one of " + designators)
+		}
+
+		number, designator := part[:i], part[i]
+		part = part[i+1:]
+
+		position := strings.IndexByte(designators, designator)
+
+		if position < order {
+			return fmt.Errorf("designator %c out of order", designator)
+		}
+
+		order = position + 1
+
+		if designator == 'S' && designators == "HMS" {
+			seconds, err := strconv.ParseFloat(strings.Replace(number, ",", ".", 1), 64)
+
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("invalid seconds %q", number)
+			}
+
+			d.time += time.Duration(seconds * float64(time.Second))
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid number %q", number)
+		}
+
+		switch {
+		case designators == "HMS" && designator == 'H':
+			d.time += time.Duration(n) * time.Hour
+		case designators == "HMS" && designator == 'M':
+			d.time += time.Duration(n) * time.Minute
+		case designator == 'Y':
+			d.years = n
+		case designator == 'M':
+			d.months = n
+		case designator == 'W':
+			d.days += 7 * n
+		case designator == 'D':
+			d.days += n
+		}
+	}
+
+	return nil
+}
+
+// parseRFC3339 accepts an RFC 3339 time, e.g. 2006-01-02T15:04:05Z.
+func parseRFC3339(value string, assertions map[string]string) error {
+	_, err := time.Parse(time.RFC3339, value)
+	return err
+}
+
+// parseDate accepts a date in the ISO 8601 calendar date format, e.g. 2006-01-02.
+func parseDate(value string, assertions map[string]string) error {
+	_, err := time.Parse(time.DateOnly, value)
+	return err
+}
@@ -0,0 +1,177 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	start := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+		err      bool
+	}{
+		{name: "scenario1", value: "P1Y2M3DT4H5M6S", expected: time.Date(2021, 4, 3, 4, 5, 6, 0, time.UTC)},
+		{name: "scenario2", value: "P2W", expected: time.Date(2020, 2, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "scenario3", value: "PT1.5S", expected: start.Add(1500 * time.Millisecond)},
+		{name: "scenario4", value: "PT36H", expected: start.Add(36 * time.Hour)},
+		{name: "scenario5", value: "P", err: true},
+		{name: "scenario6", value: "P1DT", err: true},
+		{name: "scenario7", value: "P1D2Y", err: true},
+		{name: "scenario8", value: "1D", err: true},
+		{name: "scenario9", value: "P1.5D", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parseISODuration(tt.value)
+
+			if tt.err {
+				if err == nil {
+					t.Errorf("parseISODuration(%q) = nil, expected an error", tt.value)
+				}
+				return
+			}
+
+			if actual := d.addTo(start, 1); err != nil || !actual.Equal(tt.expected) {
+				t.Errorf("parseISODuration(%q) = %v, %+v, expected %v", tt.value, actual, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertTimeBounds(t *testing.T) {
+	type Event struct {
+		Date      string    `assert:"format=date,after=2000-01-01"`
+		Expires   string    `assert:"before=now+P1Y"`
+		Starts    string    `assert:"layout=02/01/2006 15:04,before=now+24h"`
+		CreatedAt time.Time `assert:"after=2000-01-01T00:00:00Z,before=now"`
+	}
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		event    Event
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			event: Event{
+				Date:      "2020-02-29",
+				Starts:    now.Format("02/01/2006 15:04"),
+				CreatedAt: now.Add(-time.Hour),
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			event:    Event{},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario3",
+			event: Event{
+				Date:      "1999-12-31",
+				Expires:   now.AddDate(2, 0, 0).Format(time.RFC3339),
+				Starts:    now.Add(48 * time.Hour).Format("02/01/2006 15:04"),
+				CreatedAt: now.Add(time.Hour),
+			},
+			expected: &[]Violation{
+				{Field: "Event.Date", Constraint: "after", Message: "must be after 2000-01-01"},
+				{Field: "Event.Expires", Constraint: "before", Message: "must be before now+P1Y"},
+				{Field: "Event.Starts", Constraint: "before", Message: "must be before now+24h"},
+				{Field: "Event.CreatedAt", Constraint: "before", Message: "must be before now"},
+			},
+		},
+		{
+			name: "scenario4",
+			event: Event{
+				Date:    "2020-02-30",
+				Starts:  "2020-01-01",
+				Expires: "soon",
+			},
+			expected: &[]Violation{
+				{Field: "Event.Date", Constraint: "format", Message: `parsing time "2020-02-30": day out of range`},
+				{Field: "Event.Expires", Constraint: "before", Message: `parsing time "soon" as "2006-01-02": cannot parse "soon" as "2006"`},
+				{Field: "Event.Starts", Constraint: "layout", Message: `parsing time "2020-01-01" as "02/01/2006 15:04": cannot parse "20-01-01" as "/"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := Assert(tt.event)
+
+			if !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertTimeFields(t *testing.T) {
+	type Subscription struct {
+		Started   time.Time     `assert:"required=true,past=true"`
+		Renews    *time.Time    `assert:"future=true"`
+		Birthdate string        `assert:"format=date,past=true"`
+		Timeout   time.Duration `assert:"min=1s,max=30s"`
+	}
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		subscription Subscription
+		expected     *[]Violation
+	}{
+		{
+			name: "scenario1",
+			subscription: Subscription{
+				Started:   earlier,
+				Renews:    &later,
+				Birthdate: "1990-05-17",
+				Timeout:   30 * time.Second,
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			subscription: Subscription{
+				Renews:  &earlier,
+				Timeout: 500 * time.Millisecond,
+			},
+			expected: &[]Violation{
+				{Field: "Subscription.Started", Constraint: "required"},
+				{Field: "Subscription.Renews", Constraint: "future", Message: "must be in the future"},
+				{Field: "Subscription.Timeout", Constraint: "min"},
+			},
+		},
+		{
+			name: "scenario3",
+			subscription: Subscription{
+				Started:   later,
+				Birthdate: later.Format(time.DateOnly + "x"),
+				Timeout:   time.Minute,
+			},
+			expected: &[]Violation{
+				{Field: "Subscription.Started", Constraint: "past", Message: "must be in the past"},
+				{Field: "Subscription.Birthdate", Constraint: "format", Message: `parsing time "` + later.Format(time.DateOnly+"x") + `": extra text: "x"`},
+				{Field: "Subscription.Timeout", Constraint: "max"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.subscription); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The enums map contains the values registered for the named types used with the enum assertion.
+var enums = map[reflect.Type][]string{}
+
+// RegisterEnum registers the allowed values of their named type, e.g. RegisterEnum(North, South). Fields of that
+// type tagged with enum=true must hold one of the values. It should be called during initialization.
+func RegisterEnum(values ...interface{}) {
+	for _, value := range values {
+		t := reflect.TypeOf(value)
+
+		if !containsString(enums[t], fmt.Sprint(value)) {
+			enums[t] = append(enums[t], fmt.Sprint(value))
+		}
+	}
+}
+
+// assertOneOf checks that the field value is one of the values listed, e.g. oneof=N|S. The oneofci assertion compares
+// strings case-insensitively and numeric fields are compared as numbers. An empty value is skipped, leaving it to the
+// required assertion.
+func assertOneOf(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if isEmpty(val) {
+		return violations
+	}
+
+	for _, constraint := range []string{"oneof", "oneofci"} {
+		if allowed, ok := assertions[constraint]; ok {
+			violations = assertAllowed(strings.Split(allowed, "|"), constraint == "oneofci", constraint, val, name, violations, path)
+		}
+	}
+
+	return violations
+}
+
+// assertEnum checks that the field value is one of the values registered for its type with RegisterEnum. An empty
+// value is skipped, leaving it to the required assertion, and so is a type that isn't registered, which is a tag
+// error.
+func assertEnum(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["enum"] == "true" && !isEmpty(val) {
+		val = indirect(val)
+
+		if allowed, ok := enums[val.Type()]; ok {
+			violations = assertAllowed(allowed, false, "enum", val, name, violations, path)
+		}
+	}
+
+	return violations
+}
+
+// checkEnum checks that the type t of a field tagged with enum=true is registered with RegisterEnum.
+func checkEnum(assertions map[string]string, t reflect.Type) error {
+	if _, ok := enums[indirectType(t)]; assertions["enum"] == "true" && !ok {
+		return fmt.Errorf("%s isn't registered with RegisterEnum", indirectType(t))
+	}
+
+	return nil
+}
+
+// assertAllowed checks that the field value is one of the allowed values. The violation lists the allowed values and
+// suggests the closest one.
+func assertAllowed(allowed []string, fold bool, constraint string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	val = indirect(val)
+
+	if !val.IsValid() {
+		return violations
+	}
+
+	actual := fmt.Sprint(val)
+
+	for _, value := range allowed {
+		if isSameValue(actual, value, fold, isNumber(val)) {
+			return violations
+		}
+	}
+
+	message := "must be one of " + strings.Join(allowed, ", ")
+
+	if suggestion, ok := suggest(actual, allowed); ok {
+		message += fmt.Sprintf("; did you mean %q?", suggestion)
+	}
+
+	violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint, Message: message}
+	*violations = append(*violations, violation)
+
+	return violations
+}
+
+// isSameValue returns true if the actual value matches the allowed value.
+func isSameValue(actual string, allowed string, fold bool, numeric bool) bool {
+	if numeric {
+		a, errA := strconv.ParseFloat(actual, 64)
+		b, errB := strconv.ParseFloat(allowed, 64)
+
+		if errA == nil && errB == nil {
+			return a == b
+		}
+	}
+
+	if fold {
+		return strings.EqualFold(actual, allowed)
+	}
+
+	return actual == allowed
+}
+
+// suggest returns the allowed value closest to the actual value by edit distance, provided it is close enough to be
+// a likely typo.
+func suggest(actual string, allowed []string) (string, bool) {
+	if actual == "" {
+		return "", false
+	}
+
+	best, bestDistance := "", -1
+
+	for _, value := range allowed {
+		distance := editDistance(strings.ToLower(actual), strings.ToLower(value))
+
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = value, distance
+		}
+	}
+
+	// allow one edit for short values and roughly one edit for every three characters otherwise
+	limit := len([]rune(best)) / 3
+	if limit < 1 {
+		limit = 1
+	}
+
+	return best, bestDistance >= 0 && bestDistance <= limit
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Direction string
+
+const (
+	North Direction = "N"
+	South Direction = "S"
+)
+
+func init() {
+	RegisterEnum(North, South)
+}
+
+func TestAssertOneOf(t *testing.T) {
+	type args struct {
+		assertion  map[string]string
+		val        reflect.Value
+		name       string
+		violations *[]Violation
+		path       string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			args: args{
+				name:       "Direction",
+				assertion:  map[string]string{"oneof": "N|S"},
+				val:        reflect.ValueOf(struct{ Direction string }{Direction: "N"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			args: args{
+				name:       "Direction",
+				assertion:  map[string]string{"oneof": "N|S"},
+				val:        reflect.ValueOf(struct{ Direction string }{Direction: "n"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{{Field: "Direction", Constraint: "oneof", Message: `must be one of N, S; did you mean "N"?`}},
+		},
+		{
+			name: "scenario3",
+			args: args{
+				name:       "Direction",
+				assertion:  map[string]string{"oneofci": "N|S"},
+				val:        reflect.ValueOf(struct{ Direction string }{Direction: "n"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario4",
+			args: args{
+				name:       "Status",
+				assertion:  map[string]string{"oneof": "pending|approved|rejected"},
+				val:        reflect.ValueOf(struct{ Status string }{Status: "aproved"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{{Field: "Status", Constraint: "oneof", Message: `must be one of pending, approved, rejected; did you mean "approved"?`}},
+		},
+		{
+			name: "scenario5",
+			args: args{
+				name:       "Status",
+				assertion:  map[string]string{"oneof": "pending|approved|rejected"},
+				val:        reflect.ValueOf(struct{ Status string }{Status: "unknown"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{{Field: "Status", Constraint: "oneof", Message: "must be one of pending, approved, rejected"}},
+		},
+		{
+			name: "scenario6",
+			args: args{
+				name:       "Size",
+				assertion:  map[string]string{"oneof": "1|2.5|4"},
+				val:        reflect.ValueOf(struct{ Size float64 }{Size: 2.50}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario7",
+			args: args{
+				name:       "Direction",
+				assertion:  map[string]string{"oneof": "N|S"},
+				val:        reflect.ValueOf(struct{ Direction string }{Direction: ""}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario8",
+			args: args{
+				name:       "Direction",
+				assertion:  map[string]string{"oneof": "N|S", "required": "true"},
+				val:        reflect.ValueOf(struct{ Direction string }{Direction: ""}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := assertOneOf(tt.args.assertion, tt.args.val, tt.args.name, tt.args.violations, tt.args.path); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("assertOneOf() = %+v, expected %+v", *result, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertEnum(t *testing.T) {
+	type Heading struct {
+		Direction Direction `assert:"enum=true"`
+	}
+
+	if violations := Assert(Heading{Direction: South}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{{Field: "Heading.Direction", Constraint: "enum", Message: `must be one of N, S; did you mean "S"?`}}
+
+	if violations := Assert(Heading{Direction: "s"}); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
+
+func TestAssertEnumUnregistered(t *testing.T) {
+	type Rank string
+
+	type Officer struct {
+		Rank Rank `assert:"enum=true"`
+	}
+
+	if violations := Assert(Officer{Rank: "Captain"}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	message := "assert: invalid enum tag on Officer.Rank: assert.Rank isn't registered with RegisterEnum"
+
+	if err := CheckTags(Officer{}); err == nil || err.Error() != message {
+		t.Errorf("CheckTags() error = %v, expected %s", err, message)
+	}
+}
+
+func TestAssertOneOfRequired(t *testing.T) {
+	type Heading struct {
+		Direction Direction `assert:"required=true,oneof=N|S"`
+		Bearing   Direction `assert:"required=true,enum=true"`
+	}
+
+	expected := []Violation{{Field: "Heading.Direction", Constraint: "required"}, {Field: "Heading.Bearing", Constraint: "required"}}
+
+	if violations := Assert(Heading{}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
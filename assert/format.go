@@ -0,0 +1,244 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The formats map contains the parsers of the string formats as values each associated with the format name as the
+// key. A parser returns an error describing why the value isn't in the format. Options of the format assertion, e.g.
+// format=url;schemes=https, are passed to the parser.
+var formats = map[string]func(value string, assertions map[string]string) error{
+	"email":    parseEmail,
+	"url":      parseURL,
+	"uri":      parseURI,
+	"uuid":     parseUUID,
+	"hostname": parseHostname,
+	"ip":       parseIP,
+	"ipv4":     parseIPv4,
+	"ipv6":     parseIPv6,
+	"cidr":     parseCIDR,
+	"mac":      parseMAC,
+	"hostport": parseHostPort,
+	"rfc3339":  parseRFC3339,
+	"date":     parseDate,
+	"duration": parseDuration,
+
+	"json":      parseJSON,
+	"base64":    parseBase64,
+	"base64url": parseBase64URL,
+	"hex":       parseHex,
+	"regexp":    parseRegexp,
+	"semver":    parseSemver,
+	"cron":      parseCron,
+	"template":  parseTemplate,
+}
+
+// assertFormat checks that the field value, a string, is in the format specified, e.g. format=email. The violation's
+// message holds the reason the value was rejected.
+func assertFormat(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if format, ok := assertions["format"]; ok {
+		parse, ok := formats[format]
+
+		if !ok {
+			log.Printf("unknown format %s used with format validation", format)
+			return violations
+		}
+
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		if err := parse(value, assertions); err != nil {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "format", Message: err.Error()}
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// parseEmail accepts an RFC 5322 addr-spec, e.g. kirk@example.com, without a display name or angle brackets.
+func parseEmail(value string, assertions map[string]string) error {
+	address, err := mail.ParseAddress(value)
+
+	if err != nil {
+		return err
+	}
+
+	if address.Name != "" || address.Address != value {
+		return errors.New("mail: expected an address without a display name")
+	}
+
+	return nil
+}
+
+// parseURL accepts an absolute URL with a host. The schemes option lists the allowed schemes, e.g. schemes=http|https.
+func parseURL(value string, assertions map[string]string) error {
+	u, err := url.Parse(value)
+
+	if err != nil {
+		return err
+	}
+
+	if u.Host == "" {
+		return errors.New("url: missing host")
+	}
+
+	return parseScheme(u, assertions)
+}
+
+// parseURI accepts an absolute URI, which unlike a URL may not have a host, e.g. mailto:kirk@example.com. The
+// schemes option lists the allowed schemes.
+func parseURI(value string, assertions map[string]string) error {
+	u, err := url.Parse(value)
+
+	if err != nil {
+		return err
+	}
+
+	return parseScheme(u, assertions)
+}
+
+func parseScheme(u *url.URL, assertions map[string]string) error {
+	if u.Scheme == "" {
+		return errors.New("url: missing scheme")
+	}
+
+	schemes, ok := assertions["format;schemes"]
+
+	if !ok {
+		return nil
+	}
+
+	for _, scheme := range strings.Split(schemes, "|") {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("url: scheme %s is not one of %s", u.Scheme, strings.ReplaceAll(schemes, "|", ", "))
+}
+
+// parseUUID accepts an RFC 4122 UUID in its canonical 8-4-4-4-12 form. The version option requires a version, e.g.
+// version=4.
+func parseUUID(value string, assertions map[string]string) error {
+	if len(value) != 36 {
+		return errors.New("uuid: expected 36 characters")
+	}
+
+	for i, r := range value {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return fmt.Errorf("uuid: expected '-' at position %d", i)
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return fmt.Errorf("uuid: invalid character %q at position %d", r, i)
+			}
+		}
+	}
+
+	if version, ok := assertions["format;version"]; ok && string(value[14]) != version {
+		return fmt.Errorf("uuid: expected version %s, got %c", version, value[14])
+	}
+
+	return nil
+}
+
+// parseHostname accepts an RFC 1123 host name, e.g. www.example.com.
+func parseHostname(value string, assertions map[string]string) error {
+	hostname := strings.TrimSuffix(value, ".")
+
+	if len(hostname) > 253 {
+		return errors.New("hostname: longer than 253 characters")
+	}
+
+	for _, label := range strings.Split(hostname, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return fmt.Errorf("hostname: label %q must have 1 to 63 characters", label)
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("hostname: label %q can't start or end with '-'", label)
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("hostname: invalid character %q in label %q", r, label)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseIP accepts an IPv4 or IPv6 address.
+func parseIP(value string, assertions map[string]string) error {
+	_, err := netip.ParseAddr(value)
+	return err
+}
+
+// parseIPv4 accepts an IPv4 address in dotted decimal notation.
+func parseIPv4(value string, assertions map[string]string) error {
+	addr, err := netip.ParseAddr(value)
+
+	if err == nil && !addr.Is4() {
+		err = fmt.Errorf("ParseAddr(%q): not an IPv4 address", value)
+	}
+
+	return err
+}
+
+// parseIPv6 accepts an IPv6 address.
+func parseIPv6(value string, assertions map[string]string) error {
+	addr, err := netip.ParseAddr(value)
+
+	if err == nil && !addr.Is6() {
+		err = fmt.Errorf("ParseAddr(%q): not an IPv6 address", value)
+	}
+
+	return err
+}
+
+// parseCIDR accepts an IP address prefix in CIDR notation, e.g. 192.168.0.0/16.
+func parseCIDR(value string, assertions map[string]string) error {
+	_, err := netip.ParsePrefix(value)
+	return err
+}
+
+// parseMAC accepts an IEEE 802 MAC-48, EUI-48, EUI-64 or 20-octet IP over InfiniBand link-layer address.
+func parseMAC(value string, assertions map[string]string) error {
+	_, err := net.ParseMAC(value)
+	return err
+}
+
+// parseHostPort accepts a host, which is a host name or an IP address, and a port, e.g. example.com:443.
+func parseHostPort(value string, assertions map[string]string) error {
+	host, port, err := net.SplitHostPort(value)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return fmt.Errorf("hostport: invalid port %q", port)
+	}
+
+	if parseIP(host, assertions) == nil {
+		return nil
+	}
+
+	return parseHostname(host, assertions)
+}
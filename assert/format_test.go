@@ -0,0 +1,163 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		assertions map[string]string
+		valid      []string
+		invalid    []string
+	}{
+		{
+			name:    "email",
+			format:  "email",
+			valid:   []string{"kirk@example.com", "james.t.kirk+enterprise@starfleet.example.org"},
+			invalid: []string{"kirk", "Kirk <kirk@example.com>", "kirk@", "@example.com"},
+		},
+		{
+			name:    "url",
+			format:  "url",
+			valid:   []string{"https://example.com", "ftp://example.com/pub"},
+			invalid: []string{"example.com", "mailto:kirk@example.com", "https://exa mple.com"},
+		},
+		{
+			name:       "url schemes",
+			format:     "url",
+			assertions: map[string]string{"format;schemes": "http|https"},
+			valid:      []string{"https://example.com", "HTTP://example.com"},
+			invalid:    []string{"ftp://example.com"},
+		},
+		{
+			name:    "uri",
+			format:  "uri",
+			valid:   []string{"mailto:kirk@example.com", "urn:isbn:0451450523"},
+			invalid: []string{"/relative/path"},
+		},
+		{
+			name:    "uuid",
+			format:  "uuid",
+			valid:   []string{"123e4567-e89b-12d3-a456-426614174000", "F47AC10B-58CC-4372-A567-0E02B2C3D479"},
+			invalid: []string{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g"},
+		},
+		{
+			name:       "uuid version",
+			format:     "uuid",
+			assertions: map[string]string{"format;version": "4"},
+			valid:      []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+			invalid:    []string{"123e4567-e89b-12d3-a456-426614174000"},
+		},
+		{
+			name:    "hostname",
+			format:  "hostname",
+			valid:   []string{"example.com", "www.example.com.", "localhost", "1-800-flowers.com"},
+			invalid: []string{"-example.com", "example..com", "exa_mple.com"},
+		},
+		{
+			name:    "ipv4",
+			format:  "ipv4",
+			valid:   []string{"192.168.0.1"},
+			invalid: []string{"256.0.0.1", "::1", "192.168.0"},
+		},
+		{
+			name:    "ipv6",
+			format:  "ipv6",
+			valid:   []string{"::1", "2001:db8::68"},
+			invalid: []string{"192.168.0.1", "2001:db8:::68"},
+		},
+		{
+			name:    "cidr",
+			format:  "cidr",
+			valid:   []string{"192.168.0.0/16", "2001:db8::/32"},
+			invalid: []string{"192.168.0.0", "192.168.0.0/33"},
+		},
+		{
+			name:    "mac",
+			format:  "mac",
+			valid:   []string{"00:00:5e:00:53:01", "00-00-5E-00-53-01"},
+			invalid: []string{"00:00:5e:00:53"},
+		},
+		{
+			name:    "hostport",
+			format:  "hostport",
+			valid:   []string{"example.com:443", "[::1]:8080", "192.168.0.1:80"},
+			invalid: []string{"example.com", "example.com:0", "example.com:70000", "exa_mple.com:80"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, value := range tt.valid {
+				if err := formats[tt.format](value, tt.assertions); err != nil {
+					t.Errorf("%s(%q) = %+v, expected nil", tt.format, value, err)
+				}
+			}
+
+			for _, value := range tt.invalid {
+				if err := formats[tt.format](value, tt.assertions); err == nil {
+					t.Errorf("%s(%q) = nil, expected an error", tt.format, value)
+				}
+			}
+		})
+	}
+}
+
+func TestAssertFormat(t *testing.T) {
+	type args struct {
+		assertion  map[string]string
+		val        reflect.Value
+		name       string
+		violations *[]Violation
+		path       string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			args: args{
+				name:       "Email",
+				assertion:  map[string]string{"format": "email"},
+				val:        reflect.ValueOf(struct{ Email string }{Email: "kirk@example.com"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			args: args{
+				name:       "Email",
+				assertion:  map[string]string{"format": "email"},
+				val:        reflect.ValueOf(struct{ Email string }{}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario3",
+			args: args{
+				name:       "Website",
+				assertion:  map[string]string{"format": "url", "format;schemes": "https"},
+				val:        reflect.ValueOf(struct{ Website string }{Website: "http://example.com"}).Field(0),
+				violations: &[]Violation{},
+				path:       "",
+			},
+			expected: &[]Violation{{Field: "Website", Constraint: "format", Message: "url: scheme http is not one of https"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := assertFormat(tt.args.assertion, tt.args.val, tt.args.name, tt.args.violations, tt.args.path); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("assertFormat() = %+v, expected %+v", *result, *tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The dmsPattern matches a coordinate in degrees, degrees and minutes, or degrees, minutes and seconds once the sign
+// or hemisphere is removed, e.g. 35.1495, 35°8.97' or 35°08'58.2". The components can also be separated by spaces or
+// colons, e.g. 35 08 58.2 or 35:08:58.2.
+var dmsPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)(?:°?$|(?:°\s*|[\s:]+)(\d+(?:\.\d+)?)(?:['′]?$|(?:['′]\s*|[\s:]+)(\d+(?:\.\d+)?)(?:["″]|'')?$))`)
+
+// assertLatitude checks, when latitude=true, that the field value, a number or a string, is a latitude between -90
+// and 90 degrees. A string is in decimal degrees, e.g. -35.1495, or in degrees, minutes and seconds followed or
+// preceded by the hemisphere, e.g. 35°08'58.2"S.
+func assertLatitude(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["latitude"] == "true" {
+		violations = assertCoordinate("latitude", "NS", 90, val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertLongitude checks, when longitude=true, that the field value, a number or a string, is a longitude between
+// -180 and 180 degrees. A string is in decimal degrees, e.g. -89.9078, or in degrees, minutes and seconds followed or
+// preceded by the hemisphere, e.g. 89°54'28"W.
+func assertLongitude(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if assertions["longitude"] == "true" {
+		violations = assertCoordinate("longitude", "EW", 180, val, name, violations, path)
+	}
+
+	return violations
+}
+
+// assertCoordinate checks that val is a coordinate within limit degrees of zero.
+func assertCoordinate(constraint string, hemispheres string, limit float64, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	val = indirect(val)
+
+	if !val.IsValid() {
+		return violations
+	}
+
+	var degrees float64
+
+	switch {
+	case isNumber(val):
+		degrees = asFloat(val)
+	default:
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		d, err := parseCoordinate(value, hemispheres)
+
+		if err != nil {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint, Message: err.Error()}
+			*violations = append(*violations, violation)
+			return violations
+		}
+
+		degrees = d
+	}
+
+	if math.IsNaN(degrees) || math.Abs(degrees) > limit {
+		message := fmt.Sprintf("must be a %s between %v and %v degrees", constraint, -limit, limit)
+		violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint, Message: message}
+		*violations = append(*violations, violation)
+	}
+
+	return violations
+}
+
+// parseCoordinate parses a coordinate in decimal degrees or in degrees, minutes and seconds. The coordinate either has
+// a sign or one of the hemisphere letters given, the second of which makes it negative.
+func parseCoordinate(value string, hemispheres string) (float64, error) {
+	body := strings.TrimSpace(value)
+	sign := 1.0
+	hemisphere := false
+
+	if body != "" {
+		if i := strings.IndexByte(hemispheres, body[len(body)-1]); i >= 0 {
+			body, hemisphere = strings.TrimSpace(body[:len(body)-1]), true
+			sign = float64(1 - 2*i)
+		} else if i := strings.IndexByte(hemispheres, body[0]); i >= 0 {
+			body, hemisphere = strings.TrimSpace(body[1:]), true
+			sign = float64(1 - 2*i)
+		}
+	}
+
+	if !hemisphere && body != "" && (body[0] == '-' || body[0] == '+') {
+		if body[0] == '-' {
+			sign = -1
+		}
+		body = body[1:]
+	}
+
+	m := dmsPattern.FindStringSubmatch(body)
+
+	if m == nil {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+
+	degrees, _ := strconv.ParseFloat(m[1], 64)
+
+	for i, unit := range []float64{60, 3600} {
+		component := m[i+2]
+
+		if component == "" {
+			break
+		}
+
+		if strings.Contains(m[i+1], ".") {
+			return 0, fmt.Errorf("invalid coordinate %q: only the last component can have a fraction", value)
+		}
+
+		n, _ := strconv.ParseFloat(component, 64)
+
+		if n >= 60 {
+			return 0, fmt.Errorf("invalid coordinate %q: minutes and seconds must be less than 60", value)
+		}
+
+		degrees += n / unit
+	}
+
+	return sign * degrees, nil
+}
+
+// The geometryTypes slice contains the GeoJSON geometry types defined by RFC 7946.
+var geometryTypes = []string{
+	"Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon", "GeometryCollection",
+}
+
+// geoJSONError is the reason a GeoJSON geometry was rejected. Code is one of invalid_json, invalid_type,
+// invalid_coordinates, out_of_bounds, unclosed_ring or winding_order.
+type geoJSONError struct {
+	code    string
+	message string
+}
+
+func (e *geoJSONError) Error() string {
+	return e.message
+}
+
+func geoJSONErrorf(code string, format string, a ...interface{}) error {
+	return &geoJSONError{code: code, message: "geojson: " + fmt.Sprintf(format, a...)}
+}
+
+// geometry is a GeoJSON geometry object. The coordinates are decoded once the type is known.
+type geometry struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []json.RawMessage `json:"geometries"`
+}
+
+// assertGeoJSON checks that the field value is a GeoJSON geometry as defined by RFC 7946. The value is a string, a
+// []byte or a struct or map encoded to JSON. With geojson=true any geometry type is accepted, otherwise only the
+// types listed, e.g. geojson=Polygon|MultiPolygon. The winding option enforces the right-hand rule on polygons, e.g.
+// geojson=Polygon;winding=true, which RFC 7946 recommends parsers don't. The violation's code says which rule failed.
+func assertGeoJSON(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if types, ok := assertions["geojson"]; ok {
+		allowed := geometryTypes
+
+		if types != "true" {
+			allowed = strings.Split(types, "|")
+		}
+
+		data, ok := asJSONData(val)
+
+		if !ok {
+			return violations
+		}
+
+		if err := checkGeoJSON(data, allowed, assertions["geojson;winding"] == "true"); err != nil {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "geojson", Message: err.Error()}
+
+			if e, ok := err.(*geoJSONError); ok {
+				violation.Code = e.code
+			}
+
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// asJSONData returns the JSON document held by val: a string or a []byte as is, or else val encoded to JSON. It
+// returns false if val is nil or empty.
+func asJSONData(val reflect.Value) ([]byte, bool) {
+	val = indirect(val)
+
+	if !val.IsValid() {
+		return nil, false
+	}
+
+	switch {
+	case val.Kind() == reflect.String:
+		return []byte(val.String()), val.Len() > 0
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8:
+		return val.Bytes(), val.Len() > 0
+	case val.Kind() == reflect.Map && val.IsNil():
+		return nil, false
+	}
+
+	if !val.CanInterface() {
+		return nil, false
+	}
+
+	data, err := json.Marshal(val.Interface())
+
+	if err != nil {
+		log.Printf("%s:%+v", "unable to encode value for geojson validation", err)
+		return nil, false
+	}
+
+	return data, true
+}
+
+// checkGeoJSON checks that data is a geometry of one of the types allowed, and when winding is true that its polygons
+// follow the right-hand rule.
+func checkGeoJSON(data []byte, allowed []string, winding bool) error {
+	g := geometry{}
+
+	if err := json.Unmarshal(data, &g); err != nil {
+		return geoJSONErrorf("invalid_json", "%v", err)
+	}
+
+	if !containsString(allowed, g.Type) {
+		return geoJSONErrorf("invalid_type", "type %q is not one of %s", g.Type, strings.Join(allowed, ", "))
+	}
+
+	if g.Type == "GeometryCollection" {
+		if g.Geometries == nil {
+			return geoJSONErrorf("invalid_coordinates", "GeometryCollection must have geometries")
+		}
+
+		for _, member := range g.Geometries {
+			if err := checkGeoJSON(member, geometryTypes, winding); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var err error
+
+	switch g.Type {
+	case "Point":
+		var position []float64
+		if err = decodeCoordinates(g, &position); err == nil {
+			err = checkPosition(position)
+		}
+	case "MultiPoint":
+		var positions [][]float64
+		if err = decodeCoordinates(g, &positions); err == nil {
+			err = checkPositions(positions, 0)
+		}
+	case "LineString":
+		var line [][]float64
+		if err = decodeCoordinates(g, &line); err == nil {
+			err = checkPositions(line, 2)
+		}
+	case "MultiLineString":
+		var lines [][][]float64
+		if err = decodeCoordinates(g, &lines); err == nil {
+			for _, line := range lines {
+				if err = checkPositions(line, 2); err != nil {
+					break
+				}
+			}
+		}
+	case "Polygon":
+		var rings [][][]float64
+		if err = decodeCoordinates(g, &rings); err == nil {
+			err = checkPolygon(rings, winding)
+		}
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err = decodeCoordinates(g, &polygons); err == nil {
+			for _, rings := range polygons {
+				if err = checkPolygon(rings, winding); err != nil {
+					break
+				}
+			}
+		}
+	}
+
+	return err
+}
+
+// decodeCoordinates decodes the coordinates of the geometry into v.
+func decodeCoordinates(g geometry, v interface{}) error {
+	if g.Coordinates == nil {
+		return geoJSONErrorf("invalid_coordinates", "%s must have coordinates", g.Type)
+	}
+
+	if err := json.Unmarshal(g.Coordinates, v); err != nil {
+		return geoJSONErrorf("invalid_coordinates", "invalid %s coordinates", g.Type)
+	}
+
+	return nil
+}
+
+// checkPosition checks that the position has a longitude, a latitude and an optional altitude within bounds.
+func checkPosition(position []float64) error {
+	if len(position) < 2 || len(position) > 3 {
+		return geoJSONErrorf("invalid_coordinates", "position %v must have 2 or 3 elements", position)
+	}
+
+	if math.Abs(position[0]) > 180 || math.Abs(position[1]) > 90 {
+		return geoJSONErrorf("out_of_bounds", "position %v is out of bounds", position)
+	}
+
+	return nil
+}
+
+// checkPositions checks each position and that there are at least min of them.
+func checkPositions(positions [][]float64, min int) error {
+	if len(positions) < min {
+		return geoJSONErrorf("invalid_coordinates", "line must have at least %d positions", min)
+	}
+
+	for _, position := range positions {
+		if err := checkPosition(position); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPolygon checks that each ring is closed and has at least four positions, and when winding is true that the
+// polygon follows the right-hand rule: the exterior ring is counterclockwise and the holes are clockwise.
+func checkPolygon(rings [][][]float64, winding bool) error {
+	if len(rings) == 0 {
+		return geoJSONErrorf("invalid_coordinates", "polygon must have an exterior ring")
+	}
+
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return geoJSONErrorf("invalid_coordinates", "ring %d must have at least 4 positions", i)
+		}
+
+		if err := checkPositions(ring, 0); err != nil {
+			return err
+		}
+
+		first, last := ring[0], ring[len(ring)-1]
+
+		if len(first) != len(last) || !equalPositions(first, last) {
+			return geoJSONErrorf("unclosed_ring", "ring %d must end with its first position", i)
+		}
+
+		if !winding {
+			continue
+		}
+
+		counterclockwise := ringArea(ring) > 0
+
+		if i == 0 && !counterclockwise {
+			return geoJSONErrorf("winding_order", "exterior ring must be counterclockwise")
+		}
+
+		if i > 0 && counterclockwise {
+			return geoJSONErrorf("winding_order", "ring %d must be clockwise", i)
+		}
+	}
+
+	return nil
+}
+
+// ringArea returns twice the signed area of the ring, which is positive if the ring is counterclockwise.
+func ringArea(ring [][]float64) float64 {
+	area := 0.0
+
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+
+	return area
+}
+
+func equalPositions(a []float64, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
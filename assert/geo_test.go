@@ -0,0 +1,141 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertCoordinates(t *testing.T) {
+	type Place struct {
+		Latitude  string  `assert:"latitude=true"`
+		Longitude string  `assert:"longitude=true"`
+		Lat       float64 `assert:"latitude=true"`
+		Lng       float32 `assert:"longitude=true"`
+	}
+
+	tests := []struct {
+		name     string
+		place    Place
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			place:    Place{Latitude: "35.1495", Longitude: "-89.9078", Lat: -35.1495, Lng: 179.5},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			place:    Place{Latitude: `35°08'58.2"N`, Longitude: "89 54 28 W"},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario3",
+			place:    Place{Latitude: "S 35°8.97'", Longitude: "89:54:28.1E"},
+			expected: &[]Violation{},
+		},
+		{
+			name:  "scenario4",
+			place: Place{Latitude: "91", Longitude: `89°60'W`, Lat: 90.5, Lng: -181},
+			expected: &[]Violation{
+				{Field: "Place.Latitude", Constraint: "latitude", Message: "must be a latitude between -90 and 90 degrees"},
+				{Field: "Place.Longitude", Constraint: "longitude", Message: `invalid coordinate "89°60'W": minutes and seconds must be less than 60`},
+				{Field: "Place.Lat", Constraint: "latitude", Message: "must be a latitude between -90 and 90 degrees"},
+				{Field: "Place.Lng", Constraint: "longitude", Message: "must be a longitude between -180 and 180 degrees"},
+			},
+		},
+		{
+			name:  "scenario5",
+			place: Place{Latitude: "-35N", Longitude: "89.5°30'"},
+			expected: &[]Violation{
+				{Field: "Place.Latitude", Constraint: "latitude", Message: `invalid coordinate "-35N"`},
+				{Field: "Place.Longitude", Constraint: "longitude", Message: `invalid coordinate "89.5°30'": only the last component can have a fraction`},
+			},
+		},
+		{
+			name:     "scenario6",
+			place:    Place{Latitude: `35°08'58.2"S`, Longitude: `89°54'28"W`},
+			expected: &[]Violation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.place); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertGeoJSON(t *testing.T) {
+	type Geometry struct {
+		Type        string        `json:"type"`
+		Coordinates [][][]float64 `json:"coordinates"`
+	}
+
+	type Region struct {
+		Shape    string    `assert:"geojson=true;winding=true"`
+		Boundary *Geometry `assert:"geojson=Polygon|MultiPolygon"`
+	}
+
+	square := [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+
+	tests := []struct {
+		name     string
+		region   Region
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			region: Region{
+				Shape:    `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[-89.9,35.1]},{"type":"LineString","coordinates":[[0,0],[1,1]]}]}`,
+				Boundary: &Geometry{Type: "Polygon", Coordinates: square},
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			region: Region{
+				Shape:    `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`,
+				Boundary: &Geometry{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}},
+			},
+			expected: &[]Violation{
+				{Field: "Region.Shape", Constraint: "geojson", Code: "unclosed_ring", Message: "geojson: ring 0 must end with its first position"},
+			},
+		},
+		{
+			name: "scenario3",
+			region: Region{
+				Shape:    `{"type":"Point","coordinates":[190,0]}`,
+				Boundary: &Geometry{Type: "LineString"},
+			},
+			expected: &[]Violation{
+				{Field: "Region.Shape", Constraint: "geojson", Code: "out_of_bounds", Message: "geojson: position [190 0] is out of bounds"},
+				{Field: "Region.Boundary", Constraint: "geojson", Code: "invalid_type", Message: `geojson: type "LineString" is not one of Polygon, MultiPolygon`},
+			},
+		},
+		{
+			name: "scenario4",
+			region: Region{
+				Shape: `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]],[[0.5,0.5],[1.5,0.5],[1.5,1.5],[0.5,0.5]]]}`,
+			},
+			expected: &[]Violation{
+				{Field: "Region.Shape", Constraint: "geojson", Code: "winding_order", Message: "geojson: ring 1 must be clockwise"},
+			},
+		},
+		{
+			name:   "scenario5",
+			region: Region{Shape: `{"type":"Circle"`},
+			expected: &[]Violation{
+				{Field: "Region.Shape", Constraint: "geojson", Code: "invalid_json", Message: "geojson: unexpected end of JSON input"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.region); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package assert
+
+import "strings"
+
+// DefaultGroup is the group of the constraints that don't list any groups. It is the active group when no groups
+// are given.
+const DefaultGroup = "default"
+
+// The groupParents map contains the groups each group inherits from. Activating a group also activates the groups
+// it inherits from.
+var groupParents = map[string][]string{}
+
+// InheritGroups makes group inherit the constraints of the parent groups, e.g. InheritGroups("create", DefaultGroup)
+// asserts the constraints without groups as well as those in the create group. It should be called during
+// initialization.
+func InheritGroups(group string, parents ...string) {
+	groupParents[group] = append(groupParents[group], parents...)
+}
+
+// asGroups returns the set of active groups, including the groups they inherit from.
+func asGroups(groups []string) map[string]bool {
+	if len(groups) == 0 {
+		groups = []string{DefaultGroup}
+	}
+
+	active := make(map[string]bool)
+
+	for len(groups) > 0 {
+		group := groups[0]
+		groups = groups[1:]
+
+		if !active[group] {
+			active[group] = true
+			groups = append(groups, groupParents[group]...)
+		}
+	}
+
+	return active
+}
+
+// inGroups returns true if the assertion belongs to one of the active groups. The groups of an assertion are listed
+// with the groups option, e.g. required=true;groups=create|update.
+func (s *scope) inGroups(assertions map[string]string, assertion string) bool {
+	groups, ok := assertions[assertion+";groups"]
+
+	if !ok {
+		return s.groups[DefaultGroup]
+	}
+
+	for _, group := range strings.Split(groups, "|") {
+		if s.groups[group] {
+			return true
+		}
+	}
+
+	return false
+}
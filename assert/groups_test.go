@@ -0,0 +1,61 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Member struct {
+	ID       int    `assert:"forbidden=true;groups=create"`
+	Name     string `assert:"required=true"`
+	LastName string `assert:"required=true;groups=create,maxlength=3"`
+}
+
+func init() {
+	InheritGroups("signup", "create")
+}
+
+func TestAssertGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		member   Member
+		groups   []string
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			member:   Member{ID: 1},
+			groups:   nil,
+			expected: &[]Violation{{Field: "Member.Name", Constraint: "required"}},
+		},
+		{
+			name:   "scenario2",
+			member: Member{ID: 1, Name: "Kirk"},
+			groups: []string{"create"},
+			expected: &[]Violation{
+				{Field: "Member.ID", Constraint: "forbidden"},
+				{Field: "Member.LastName", Constraint: "required"},
+			},
+		},
+		{
+			name:     "scenario3",
+			member:   Member{LastName: "James"},
+			groups:   []string{"create", DefaultGroup},
+			expected: &[]Violation{{Field: "Member.Name", Constraint: "required"}, {Field: "Member.LastName", Constraint: "maxlength"}},
+		},
+		{
+			name:     "scenario4",
+			member:   Member{ID: 1, Name: "Kirk", LastName: "Kir"},
+			groups:   []string{"signup"},
+			expected: &[]Violation{{Field: "Member.ID", Constraint: "forbidden"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.member, tt.groups...); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,499 @@
+package assert
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The identifiers map contains the checks of the identifiers used with the identifier assertion as values each
+// associated with the identifier name as the key. A check returns an identifierError saying whether the format or
+// the checksum of the value failed.
+var identifiers = map[string]func(value string, assertions map[string]string) error{
+	"card":    checkCard,
+	"luhn":    checkLuhnNumber,
+	"iban":    checkIBAN,
+	"isbn":    checkISBN,
+	"isbn10":  checkISBN10,
+	"isbn13":  checkISBN13,
+	"ean":     checkEAN,
+	"ean8":    checkEAN8,
+	"ean13":   checkEAN13,
+	"cnid":    checkCNID,
+	"brcpf":   checkBRCPF,
+	"esdni":   checkESDNI,
+	"nlbsn":   checkNLBSN,
+	"plpesel": checkPLPESEL,
+}
+
+// identifierError is the reason an identifier was rejected. Code is one of invalid_format, invalid_checksum or
+// unsupported_brand.
+type identifierError struct {
+	code    string
+	message string
+}
+
+func (e *identifierError) Error() string {
+	return e.message
+}
+
+func formatError(format string, a ...interface{}) error {
+	return &identifierError{code: "invalid_format", message: fmt.Sprintf(format, a...)}
+}
+
+func checksumError(format string, a ...interface{}) error {
+	return &identifierError{code: "invalid_checksum", message: fmt.Sprintf(format, a...)}
+}
+
+// cardBrand describes the numbers issued by a card brand.
+type cardBrand struct {
+	name     string
+	prefixes [][2]int
+	lengths  []int
+}
+
+// The cardBrands slice contains the issuer identification number ranges of the card brands. Brands with narrower
+// ranges come first, as the first matching brand is the one detected.
+var cardBrands = []cardBrand{
+	{name: "amex", prefixes: [][2]int{{34, 34}, {37, 37}}, lengths: []int{15}},
+	{name: "diners", prefixes: [][2]int{{300, 305}, {36, 36}, {38, 39}}, lengths: []int{14, 15, 16, 17, 18, 19}},
+	{name: "jcb", prefixes: [][2]int{{3528, 3589}}, lengths: []int{16, 17, 18, 19}},
+	{name: "discover", prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, lengths: []int{16, 17, 18, 19}},
+	{name: "unionpay", prefixes: [][2]int{{62, 62}}, lengths: []int{16, 17, 18, 19}},
+	{name: "mastercard", prefixes: [][2]int{{51, 55}, {2221, 2720}}, lengths: []int{16}},
+	{name: "maestro", prefixes: [][2]int{{50, 50}, {56, 69}}, lengths: []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{name: "visa", prefixes: [][2]int{{4, 4}}, lengths: []int{13, 16, 19}},
+}
+
+// The ibanLengths map contains the length of the IBANs of each country as values each associated with the ISO
+// 3166-1 alpha-2 country code as the key.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// assertIdentifier checks that the field value, a string, is a valid identifier of the type specified, e.g.
+// identifier=iban. The violation's code says whether the format or the checksum failed.
+func assertIdentifier(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if identifier, ok := assertions["identifier"]; ok {
+		check, ok := identifiers[identifier]
+
+		if !ok {
+			log.Printf("unknown identifier %s used with identifier validation", identifier)
+			return violations
+		}
+
+		value, ok := asStringValue(val)
+
+		if !ok || value == "" {
+			return violations
+		}
+
+		if err := check(value, assertions); err != nil {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: "identifier", Message: err.Error()}
+
+			if e, ok := err.(*identifierError); ok {
+				violation.Code = e.code
+			}
+
+			*violations = append(*violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// checkCard accepts a payment card number of a known brand with a valid Luhn check digit. Spaces and hyphens between
+// the digits are ignored. The brands option lists the accepted brands, e.g. brands=visa|mastercard.
+func checkCard(value string, assertions map[string]string) error {
+	number := stripSeparators(value)
+
+	if !isDigits(number) {
+		return formatError("card number must only have digits")
+	}
+
+	brand, ok := detectCardBrand(number)
+
+	if !ok {
+		return formatError("card number doesn't match a known brand")
+	}
+
+	if brands, ok := assertions["identifier;brands"]; ok && !isSubset([]string{brand}, strings.Split(brands, "|")) {
+		return &identifierError{code: "unsupported_brand", message: fmt.Sprintf("card brand %s is not one of %s", brand, strings.ReplaceAll(brands, "|", ", "))}
+	}
+
+	if !isLuhn(number) {
+		return checksumError("card number has an invalid check digit")
+	}
+
+	return nil
+}
+
+// detectCardBrand returns the brand of the card number, matched by prefix and length.
+func detectCardBrand(number string) (string, bool) {
+	for _, brand := range cardBrands {
+		if !containsInt(brand.lengths, len(number)) {
+			continue
+		}
+
+		for _, prefix := range brand.prefixes {
+			digits := len(strconv.Itoa(prefix[0]))
+
+			if n, _ := strconv.Atoi(number[:digits]); n >= prefix[0] && n <= prefix[1] {
+				return brand.name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// checkLuhnNumber accepts a number of digits with a valid Luhn check digit.
+func checkLuhnNumber(value string, assertions map[string]string) error {
+	if !isDigits(value) || len(value) < 2 {
+		return formatError("must be at least two digits")
+	}
+
+	if !isLuhn(value) {
+		return checksumError("invalid Luhn check digit")
+	}
+
+	return nil
+}
+
+// checkIBAN accepts an International Bank Account Number of the length used by its country with valid ISO 7064
+// MOD 97-10 check digits. Spaces are ignored and letters may be in any case.
+func checkIBAN(value string, assertions map[string]string) error {
+	iban := strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+
+	if len(iban) < 5 || !isUpperLetters(iban[:2]) || !isDigits(iban[2:4]) || !isAlphanumeric(iban[4:]) {
+		return formatError("IBAN must be a country code, two check digits and an alphanumeric account number")
+	}
+
+	length, ok := ibanLengths[iban[:2]]
+
+	if !ok {
+		return formatError("IBAN country %s is unknown", iban[:2])
+	}
+
+	if len(iban) != length {
+		return formatError("IBAN of country %s must have %d characters", iban[:2], length)
+	}
+
+	if mod97(iban[4:]+iban[:4]) != 1 {
+		return checksumError("IBAN has invalid check digits")
+	}
+
+	return nil
+}
+
+// checkISBN accepts an ISBN-10 or an ISBN-13.
+func checkISBN(value string, assertions map[string]string) error {
+	if len(stripSeparators(value)) == 10 {
+		return checkISBN10(value, assertions)
+	}
+	return checkISBN13(value, assertions)
+}
+
+// checkISBN10 accepts an ISBN-10 with a valid mod 11 check digit, which may be X. Spaces and hyphens are ignored.
+func checkISBN10(value string, assertions map[string]string) error {
+	isbn := stripSeparators(value)
+
+	if len(isbn) != 10 || !isDigits(isbn[:9]) || !isDigits(isbn[9:]) && isbn[9] != 'X' && isbn[9] != 'x' {
+		return formatError("ISBN-10 must be nine digits followed by a digit or X")
+	}
+
+	sum := 0
+
+	for i := 0; i < 10; i++ {
+		d := 10
+		if i < 9 || isDigits(isbn[9:]) {
+			d = int(isbn[i] - '0')
+		}
+		sum += (10 - i) * d
+	}
+
+	if sum%11 != 0 {
+		return checksumError("ISBN-10 has an invalid check digit")
+	}
+
+	return nil
+}
+
+// checkISBN13 accepts an ISBN-13, which is an EAN-13 starting with 978 or 979. Spaces and hyphens are ignored.
+func checkISBN13(value string, assertions map[string]string) error {
+	isbn := stripSeparators(value)
+
+	if len(isbn) != 13 || !isDigits(isbn) || !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return formatError("ISBN-13 must be 13 digits starting with 978 or 979")
+	}
+
+	if !isGTIN(isbn) {
+		return checksumError("ISBN-13 has an invalid check digit")
+	}
+
+	return nil
+}
+
+// checkEAN accepts an EAN-8 or an EAN-13.
+func checkEAN(value string, assertions map[string]string) error {
+	if len(value) == 8 {
+		return checkEAN8(value, assertions)
+	}
+	return checkEAN13(value, assertions)
+}
+
+// checkEAN8 accepts an EAN-8 with a valid check digit.
+func checkEAN8(value string, assertions map[string]string) error {
+	if len(value) != 8 || !isDigits(value) {
+		return formatError("EAN-8 must be 8 digits")
+	}
+
+	if !isGTIN(value) {
+		return checksumError("EAN-8 has an invalid check digit")
+	}
+
+	return nil
+}
+
+// checkEAN13 accepts an EAN-13 with a valid check digit.
+func checkEAN13(value string, assertions map[string]string) error {
+	if len(value) != 13 || !isDigits(value) {
+		return formatError("EAN-13 must be 13 digits")
+	}
+
+	if !isGTIN(value) {
+		return checksumError("EAN-13 has an invalid check digit")
+	}
+
+	return nil
+}
+
+// checkCNID accepts a resident identity card number of the People's Republic of China with a valid ISO 7064 MOD 11-2
+// check character, which may be X.
+func checkCNID(value string, assertions map[string]string) error {
+	if len(value) != 18 || !isDigits(value[:17]) || !isDigits(value[17:]) && value[17] != 'X' && value[17] != 'x' {
+		return formatError("resident identity number must be 17 digits followed by a digit or X")
+	}
+
+	if mod11_2(value[:17]) != strings.ToUpper(value[17:]) {
+		return checksumError("resident identity number has an invalid check character")
+	}
+
+	return nil
+}
+
+// checkBRCPF accepts a Brazilian individual taxpayer number (CPF), e.g. 529.982.247-25, with valid MOD 11 check
+// digits.
+func checkBRCPF(value string, assertions map[string]string) error {
+	cpf := strings.NewReplacer(".", "", "-", "").Replace(value)
+
+	if len(cpf) != 11 || !isDigits(cpf) {
+		return formatError("CPF must be 11 digits")
+	}
+
+	if strings.Count(cpf, cpf[:1]) == len(cpf) {
+		return formatError("CPF can't repeat a single digit")
+	}
+
+	for n := 9; n <= 10; n++ {
+		sum := 0
+
+		for i := 0; i < n; i++ {
+			sum += int(cpf[i]-'0') * (n + 1 - i)
+		}
+
+		if sum*10%11%10 != int(cpf[n]-'0') {
+			return checksumError("CPF has invalid check digits")
+		}
+	}
+
+	return nil
+}
+
+// dniLetters are the check letters of Spanish identity numbers indexed by the number modulo 23.
+const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+// checkESDNI accepts a Spanish national identity number (DNI), 8 digits and a check letter, or a foreigner identity
+// number (NIE), X, Y or Z followed by 7 digits and a check letter.
+func checkESDNI(value string, assertions map[string]string) error {
+	dni := strings.ToUpper(stripSeparators(value))
+
+	if len(dni) != 9 || !isUpperLetters(dni[8:]) {
+		return formatError("DNI must be 8 digits, or X, Y or Z and 7 digits, followed by a letter")
+	}
+
+	// the letter of a NIE stands for the first digit of the number
+	number := strings.NewReplacer("X", "0", "Y", "1", "Z", "2").Replace(dni[:1]) + dni[1:8]
+
+	if !isDigits(number) {
+		return formatError("DNI must be 8 digits, or X, Y or Z and 7 digits, followed by a letter")
+	}
+
+	n, _ := strconv.Atoi(number)
+
+	if dniLetters[n%23] != dni[8] {
+		return checksumError("DNI has an invalid check letter")
+	}
+
+	return nil
+}
+
+// checkNLBSN accepts a Dutch citizen service number (BSN) of 9 digits that passes the eleven test.
+func checkNLBSN(value string, assertions map[string]string) error {
+	bsn := strings.ReplaceAll(value, ".", "")
+
+	if len(bsn) != 9 || !isDigits(bsn) {
+		return formatError("BSN must be 9 digits")
+	}
+
+	sum := -int(bsn[8] - '0')
+
+	for i := 0; i < 8; i++ {
+		sum += int(bsn[i]-'0') * (9 - i)
+	}
+
+	if sum%11 != 0 {
+		return checksumError("BSN fails the eleven test")
+	}
+
+	return nil
+}
+
+// checkPLPESEL accepts a Polish national identification number (PESEL) of 11 digits with a valid check digit.
+func checkPLPESEL(value string, assertions map[string]string) error {
+	if len(value) != 11 || !isDigits(value) {
+		return formatError("PESEL must be 11 digits")
+	}
+
+	weights := []int{1, 3, 7, 9, 1, 3, 7, 9, 1, 3}
+	sum := 0
+
+	for i, weight := range weights {
+		sum += int(value[i]-'0') * weight
+	}
+
+	if (10-sum%10)%10 != int(value[10]-'0') {
+		return checksumError("PESEL has an invalid check digit")
+	}
+
+	return nil
+}
+
+// isLuhn returns true if the last digit of number is its Luhn check digit.
+func isLuhn(number string) bool {
+	sum := 0
+
+	for i := 0; i < len(number); i++ {
+		d := int(number[len(number)-1-i] - '0')
+
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// isGTIN returns true if the last digit of number is its GS1 check digit, as used by EAN-8, EAN-13 and ISBN-13.
+func isGTIN(number string) bool {
+	sum := 0
+
+	for i := 0; i < len(number); i++ {
+		d := int(number[len(number)-1-i] - '0')
+
+		if i%2 == 1 {
+			d *= 3
+		}
+
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// mod97 returns the ISO 7064 MOD 97-10 remainder of value, with letters converted to the numbers 10 to 35.
+func mod97(value string) int {
+	remainder := 0
+
+	for _, r := range value {
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+
+	return remainder
+}
+
+// mod11_2 returns the ISO 7064 MOD 11-2 check character of digits.
+func mod11_2(digits string) string {
+	p := 0
+
+	for _, r := range digits {
+		p = ((p + int(r-'0')) * 2) % 11
+	}
+
+	check := (12 - p) % 11
+
+	if check == 10 {
+		return "X"
+	}
+
+	return strconv.Itoa(check)
+}
+
+// stripSeparators removes the spaces and hyphens used to group digits.
+func stripSeparators(value string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+func isUpperLetters(value string) bool {
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return value != ""
+}
+
+func containsInt(values []int, n int) bool {
+	for _, value := range values {
+		if value == n {
+			return true
+		}
+	}
+	return false
+}
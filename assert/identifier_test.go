@@ -0,0 +1,133 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestIdentifiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		assertions map[string]string
+		valid      []string
+		format     []string
+		checksum   []string
+	}{
+		{
+			name:       "card",
+			identifier: "card",
+			valid:      []string{"4111 1111 1111 1111", "5555555555554444", "2223003122003222", "378282246310005", "6011111111111117", "3530111333300000"},
+			format:     []string{"4111-1111-1111", "9111111111111111", "4111a11111111111"},
+			checksum:   []string{"4111111111111112", "378282246310006"},
+		},
+		{
+			name:       "card brands",
+			identifier: "card",
+			assertions: map[string]string{"identifier;brands": "visa|mastercard"},
+			valid:      []string{"4111111111111111", "5555555555554444"},
+		},
+		{
+			name:       "luhn",
+			identifier: "luhn",
+			valid:      []string{"79927398713"},
+			format:     []string{"7992739871a", "7"},
+			checksum:   []string{"79927398710"},
+		},
+		{
+			name:       "iban",
+			identifier: "iban",
+			valid:      []string{"GB82 WEST 1234 5698 7654 32", "DE89370400440532013000", "fr1420041010050500013m02606"},
+			format:     []string{"GB82WEST123456987654", "ZZ82WEST12345698765432", "GB8!WEST12345698765432"},
+			checksum:   []string{"GB82WEST12345698765433", "DE89370400440532013001"},
+		},
+		{
+			name:       "isbn",
+			identifier: "isbn",
+			valid:      []string{"0-306-40615-2", "080442957X", "978-0-306-40615-7"},
+			format:     []string{"0-306-40615", "123-0-306-40615-7"},
+			checksum:   []string{"0-306-40615-3", "978-0-306-40615-8"},
+		},
+		{
+			name:       "ean",
+			identifier: "ean",
+			valid:      []string{"4006381333931", "96385074"},
+			format:     []string{"400638133393", "400638133393a"},
+			checksum:   []string{"4006381333932", "96385075"},
+		},
+		{
+			name:       "cnid",
+			identifier: "cnid",
+			valid:      []string{"11010519491231002X", "440304198506153216"},
+			format:     []string{"11010519491231002", "1101051949123100ZX"},
+			checksum:   []string{"110105194912310021"},
+		},
+		{
+			name:       "brcpf",
+			identifier: "brcpf",
+			valid:      []string{"529.982.247-25", "11144477735"},
+			format:     []string{"529.982.247", "111.111.111-11", "529.982.247-2a"},
+			checksum:   []string{"529.982.247-26"},
+		},
+		{
+			name:       "esdni",
+			identifier: "esdni",
+			valid:      []string{"12345678Z", "12345678-z", "X1234567L", "Y1234567X"},
+			format:     []string{"1234567Z", "123456789", "W1234567L"},
+			checksum:   []string{"12345678A", "X1234567X"},
+		},
+		{
+			name:       "nlbsn",
+			identifier: "nlbsn",
+			valid:      []string{"111222333", "1234.56.782"},
+			format:     []string{"11122233", "11122233a"},
+			checksum:   []string{"111222334"},
+		},
+		{
+			name:       "plpesel",
+			identifier: "plpesel",
+			valid:      []string{"44051401359", "02070803628"},
+			format:     []string{"4405140135", "4405140135x"},
+			checksum:   []string{"44051401358"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := identifiers[tt.identifier]
+
+			for _, value := range tt.valid {
+				if err := check(value, tt.assertions); err != nil {
+					t.Errorf("%s(%q) = %+v, expected nil", tt.identifier, value, err)
+				}
+			}
+
+			for _, value := range tt.format {
+				if err, ok := check(value, tt.assertions).(*identifierError); !ok || err.code != "invalid_format" {
+					t.Errorf("%s(%q) = %+v, expected an invalid_format error", tt.identifier, value, err)
+				}
+			}
+
+			for _, value := range tt.checksum {
+				if err, ok := check(value, tt.assertions).(*identifierError); !ok || err.code != "invalid_checksum" {
+					t.Errorf("%s(%q) = %+v, expected an invalid_checksum error", tt.identifier, value, err)
+				}
+			}
+		})
+	}
+}
+
+func TestAssertIdentifier(t *testing.T) {
+	type Payment struct {
+		CardNumber string `assert:"identifier=card;brands=visa|mastercard"`
+		IBAN       string `assert:"identifier=iban"`
+	}
+
+	expected := []Violation{
+		{Field: "Payment.CardNumber", Constraint: "identifier", Code: "unsupported_brand", Message: "card brand amex is not one of visa, mastercard"},
+		{Field: "Payment.IBAN", Constraint: "identifier", Code: "invalid_checksum", Message: "IBAN has invalid check digits"},
+	}
+
+	if violations := Assert(Payment{CardNumber: "378282246310005", IBAN: "GB82WEST12345698765433"}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
@@ -0,0 +1,183 @@
+package assert
+
+import (
+	"log"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// DecimalFunc converts a value of a numeric type registered with RegisterDecimal to an exact rational number. v holds
+// a value of that type. It returns false if the value isn't a finite number.
+type DecimalFunc func(v interface{}) (*big.Rat, bool)
+
+// The decimalFns map contains the conversions of the numeric types registered with RegisterDecimal.
+var decimalFns = map[reflect.Type]DecimalFunc{}
+
+// decimalPattern matches a decimal number with an optional exponent, e.g. -12.50 or 1e6, the syntax of json.Number.
+var decimalPattern = regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?$`)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+// RegisterDecimal registers a numeric type, the type of v, for the min, max, gt, lt and multipleof assertions, e.g.
+// a decimal type from a third-party package. It replaces any function registered for the type. It should be called
+// during initialization.
+func RegisterDecimal(v interface{}, fn DecimalFunc) {
+	decimalFns[reflect.TypeOf(v)] = fn
+}
+
+// assertGt checks that the value is greater than the value specified, e.g. gt=0.
+func assertGt(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	return assertBound(assertions, "gt", func(c int) bool { return c > 0 }, val, name, violations, path)
+}
+
+// assertLt checks that the value is less than the value specified, e.g. lt=100.
+func assertLt(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	return assertBound(assertions, "lt", func(c int) bool { return c < 0 }, val, name, violations, path)
+}
+
+// assertMultipleOf checks that the value is a multiple of the value specified, e.g. multipleof=0.01 for an amount in
+// whole cents.
+func assertMultipleOf(assertions map[string]string, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if _, ok := assertions["multipleof"]; !ok {
+		return violations
+	}
+
+	step, ok := parseDecimal(assertions["multipleof"])
+
+	if !ok || step.Sign() == 0 {
+		log.Printf("unable to parse multipleof tag value %s", assertions["multipleof"])
+		return violations
+	}
+
+	return assertNumber("multipleof", val, name, violations, path, func(n *big.Rat) bool {
+		return new(big.Rat).Quo(n, step).IsInt()
+	})
+}
+
+// assertBound checks that ok holds for the comparison of the value with the bound of the constraint, which is
+// negative, zero or positive as the value is less than, equal to or greater than the bound. The comparison is exact.
+func assertBound(assertions map[string]string, constraint string, ok func(c int) bool, val reflect.Value, name string, violations *[]Violation, path string) *[]Violation {
+	if _, found := assertions[constraint]; !found {
+		return violations
+	}
+
+	bound, found := parseDecimal(assertions[constraint])
+
+	if !found {
+		log.Printf("unable to parse %s tag value %s", constraint, assertions[constraint])
+		return violations
+	}
+
+	return assertNumber(constraint, val, name, violations, path, func(n *big.Rat) bool {
+		return ok(n.Cmp(bound))
+	})
+}
+
+// assertNumber reports a violation of the constraint if the number held by val doesn't satisfy ok. A string that isn't
+// a decimal number is reported as such.
+func assertNumber(constraint string, val reflect.Value, name string, violations *[]Violation, path string, ok func(n *big.Rat) bool) *[]Violation {
+	val = indirect(val)
+
+	if !val.IsValid() {
+		return violations
+	}
+
+	n, isNumber := asRat(val)
+
+	if !isNumber {
+		if val.Kind() != reflect.String {
+			log.Printf("invalid field type used with %s validation", constraint)
+			return violations
+		}
+
+		if val.Len() > 0 {
+			violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint, Message: "must be a decimal number"}
+			*violations = append(*violations, violation)
+		}
+
+		return violations
+	}
+
+	if !ok(n) {
+		violation := Violation{Field: asQualifiedPath(path, name), Constraint: constraint}
+		*violations = append(*violations, violation)
+	}
+
+	return violations
+}
+
+// asRat returns the exact value of the number held by val: an integer, a float, a big.Int, big.Float or big.Rat, a
+// type registered with RegisterDecimal, or a decimal string such as a json.Number. A float is taken as the shortest
+// decimal that represents it, so a float64 holding 0.1 equals the bound 0.1. It returns false for other values and
+// for infinities and NaN. The integers and floats of unexported fields are read too.
+func asRat(val reflect.Value) (*big.Rat, bool) {
+	if fn, ok := decimalFns[val.Type()]; ok && val.CanInterface() {
+		return fn(val.Interface())
+	}
+
+	switch {
+	case isInt(val):
+		return new(big.Rat).SetInt64(val.Int()), true
+	case isUint(val):
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(val.Uint())), true
+	case val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64:
+		f := val.Float()
+
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, false
+		}
+
+		return parseDecimal(strconv.FormatFloat(f, 'g', -1, val.Type().Bits()))
+	}
+
+	if !val.CanInterface() {
+		return nil, false
+	}
+
+	switch {
+	case val.Type() == bigIntType:
+		return new(big.Rat).SetInt(asPointer(val).(*big.Int)), true
+	case val.Type() == bigFloatType:
+		f := asPointer(val).(*big.Float)
+
+		if f.IsInf() {
+			return nil, false
+		}
+
+		r, _ := f.Rat(nil)
+		return r, true
+	case val.Type() == bigRatType:
+		return new(big.Rat).Set(asPointer(val).(*big.Rat)), true
+	case val.Kind() == reflect.String:
+		return parseDecimal(val.String())
+	}
+
+	return nil, false
+}
+
+// parseDecimal parses a decimal number exactly.
+func parseDecimal(value string) (*big.Rat, bool) {
+	if !decimalPattern.MatchString(value) {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(value)
+}
+
+// asPointer returns a pointer to val, copying val when it isn't addressable.
+func asPointer(val reflect.Value) interface{} {
+	if val.CanAddr() {
+		return val.Addr().Interface()
+	}
+
+	ptr := reflect.New(val.Type())
+	ptr.Elem().Set(val)
+	return ptr.Interface()
+}
@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+// Money is a decimal type registered with RegisterDecimal, holding an amount in units and hundredths.
+type Money struct {
+	Units int64
+	Cents int64
+}
+
+func init() {
+	RegisterDecimal(Money{}, func(v interface{}) (*big.Rat, bool) {
+		m := v.(Money)
+		return big.NewRat(m.Units*100+m.Cents, 100), true
+	})
+}
+
+func TestAssertNumbers(t *testing.T) {
+	type Invoice struct {
+		ID       *big.Int    `assert:"gt=0"`
+		Total    json.Number `assert:"min=0.01,max=99999999999999999999.99,multipleof=0.01"`
+		Rate     *big.Rat    `assert:"gt=0,lt=1"`
+		Weight   *big.Float  `assert:"max=1e3"`
+		Discount string      `assert:"min=0,lt=100"`
+		Quantity uint64      `assert:"max=18446744073709551614"`
+		Price    float64     `assert:"max=0.3,multipleof=0.1"`
+		Paid     Money       `assert:"max=10.50"`
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name     string
+		invoice  Invoice
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			invoice: Invoice{
+				ID:       huge,
+				Total:    "99999999999999999999.99",
+				Rate:     big.NewRat(1, 3),
+				Weight:   big.NewFloat(1000),
+				Discount: "12.5",
+				Quantity: 18446744073709551614,
+				Price:    0.3,
+				Paid:     Money{Units: 10, Cents: 50},
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			invoice: Invoice{
+				ID:       big.NewInt(0),
+				Total:    "100000000000000000000.001",
+				Rate:     big.NewRat(1, 1),
+				Weight:   big.NewFloat(1000.5),
+				Discount: "12,5",
+				Quantity: 18446744073709551615,
+				Price:    0.35,
+				Paid:     Money{Units: 10, Cents: 51},
+			},
+			expected: &[]Violation{
+				{Field: "Invoice.ID", Constraint: "gt"},
+				{Field: "Invoice.Total", Constraint: "max"},
+				{Field: "Invoice.Total", Constraint: "multipleof"},
+				{Field: "Invoice.Rate", Constraint: "lt"},
+				{Field: "Invoice.Weight", Constraint: "max"},
+				{Field: "Invoice.Discount", Constraint: "min", Message: "must be a decimal number"},
+				{Field: "Invoice.Discount", Constraint: "lt", Message: "must be a decimal number"},
+				{Field: "Invoice.Quantity", Constraint: "max"},
+				{Field: "Invoice.Price", Constraint: "max"},
+				{Field: "Invoice.Price", Constraint: "multipleof"},
+				{Field: "Invoice.Paid", Constraint: "max"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.invoice); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertNumbersUnexported(t *testing.T) {
+	type Engine struct {
+		warp   int     `assert:"min=1,max=9"`
+		crew   uint16  `assert:"max=430"`
+		output float64 `assert:"gt=0"`
+	}
+
+	if violations := Assert(Engine{warp: 9, crew: 430, output: 0.5}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{
+		{Field: "Engine.warp", Constraint: "max"},
+		{Field: "Engine.crew", Constraint: "max"},
+		{Field: "Engine.output", Constraint: "gt"},
+	}
+
+	if violations := Assert(Engine{warp: 10, crew: 431}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
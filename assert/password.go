@@ -0,0 +1,194 @@
+package assert
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed data/passwords.txt
+var passwordsTXT string
+
+// PasswordPolicy holds the rules of a named password policy used with the password assertion. A zero rule isn't
+// checked. Classes lists the character classes the password must contain: lower, upper, caseless, digit and symbol.
+// MaxRepeat is the longest run of the same character allowed. Blocklist lists rejected passwords, compared
+// case-insensitively. MinEntropy is the minimum number of bits of the entropy estimated from the length and the classes
+// used.
+type PasswordPolicy struct {
+	MinLength  int
+	Classes    []string
+	MaxRepeat  int
+	Blocklist  []string
+	MinEntropy float64
+}
+
+// passwordPolicy is a registered PasswordPolicy with its blocklist indexed.
+type passwordPolicy struct {
+	PasswordPolicy
+	blocked map[string]bool
+}
+
+// passwordClass is a character class a password policy can require, with the number of characters it holds for the
+// entropy estimate.
+type passwordClass struct {
+	name        string
+	description string
+	size        float64
+	is          func(r rune) bool
+}
+
+// The passwordClasses slice contains the character classes of the password policies. A letter without case, e.g. a
+// CJK ideograph, is caseless, and a character that isn't a letter or a digit is a symbol.
+var passwordClasses = []passwordClass{
+	{name: "lower", description: "a lowercase letter", size: 26, is: unicode.IsLower},
+	{name: "upper", description: "an uppercase letter", size: 26, is: unicode.IsUpper},
+	{name: "caseless", description: "a letter without case", size: 100, is: func(r rune) bool {
+		return unicode.IsLetter(r) && !unicode.IsLower(r) && !unicode.IsUpper(r)
+	}},
+	{name: "digit", description: "a digit", size: 10, is: unicode.IsDigit},
+	{name: "symbol", description: "a symbol", size: 33, is: func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}},
+}
+
+// The passwordPolicies map contains the policies registered with RegisterPasswordPolicy associated with their names.
+// The default policy checks the length, repeated runs and the embedded blocklist, and the strong policy adds character
+// classes and an entropy estimate.
+var passwordPolicies = map[string]*passwordPolicy{}
+
+func init() {
+	RegisterPasswordPolicy("default", PasswordPolicy{MinLength: 8, MaxRepeat: 3, Blocklist: CommonPasswords()})
+	RegisterPasswordPolicy("strong", PasswordPolicy{
+		MinLength:  12,
+		Classes:    []string{"lower", "upper", "digit", "symbol"},
+		MaxRepeat:  2,
+		Blocklist:  CommonPasswords(),
+		MinEntropy: 60,
+	})
+}
+
+// RegisterPasswordPolicy registers the policy under name, replacing any policy registered under the same name.
+// Fields tagged with password=name must satisfy each of its rules. It should be called during initialization.
+func RegisterPasswordPolicy(name string, policy PasswordPolicy) {
+	blocked := make(map[string]bool)
+
+	for _, password := range policy.Blocklist {
+		blocked[strings.ToLower(password)] = true
+	}
+
+	passwordPolicies[name] = &passwordPolicy{PasswordPolicy: policy, blocked: blocked}
+}
+
+// CommonPasswords returns the embedded list of common passwords.
+func CommonPasswords() []string {
+	return readPasswords(bufio.NewScanner(strings.NewReader(passwordsTXT)))
+}
+
+// LoadBlocklist reads a list of passwords from a local file, one per line. Blank lines and lines starting with # are
+// skipped.
+func LoadBlocklist(name string) ([]string, error) {
+	file, err := os.Open(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	passwords := readPasswords(scanner)
+
+	return passwords, scanner.Err()
+}
+
+func readPasswords(scanner *bufio.Scanner) []string {
+	passwords := make([]string, 0)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		passwords = append(passwords, line)
+	}
+
+	return passwords
+}
+
+// assertPassword checks that the field value, a string, satisfies the rules of the password policy specified, e.g.
+// password=strong. Each failed rule is reported by its own violation, whose code names the rule. The violations
+// never include the value, and validate clears the messages of the other constraints on the field.
+func assertPassword(assertions map[string]string, f field, s *scope) {
+	name := assertions["password"]
+	policy, ok := passwordPolicies[name]
+
+	if !ok {
+		log.Printf("unknown policy %s used with password validation", name)
+		return
+	}
+
+	value, ok := asStringValue(f.val)
+
+	if !ok || value == "" {
+		return
+	}
+
+	length := utf8.RuneCountInString(value)
+
+	if policy.MinLength > 0 && length < policy.MinLength {
+		s.reportCode(f, "password", "too_short", fmt.Sprintf("must be at least %d characters long", policy.MinLength))
+	}
+
+	pool := 0.0
+
+	for _, class := range passwordClasses {
+		has := strings.IndexFunc(value, class.is) >= 0
+
+		if has {
+			pool += class.size
+		}
+
+		if !has && containsString(policy.Classes, class.name) {
+			s.reportCode(f, "password", "missing_"+class.name, "must contain "+class.description)
+		}
+	}
+
+	if policy.MaxRepeat > 0 && longestRun(value) > policy.MaxRepeat {
+		s.reportCode(f, "password", "repeated_characters", fmt.Sprintf("must not repeat a character more than %d times in a row", policy.MaxRepeat))
+	}
+
+	if policy.blocked[strings.ToLower(value)] {
+		s.reportCode(f, "password", "common_password", "must not be a commonly used password")
+	}
+
+	if policy.MinEntropy > 0 && float64(length)*math.Log2(pool) < policy.MinEntropy {
+		s.reportCode(f, "password", "low_entropy", "must be longer or use more kinds of characters")
+	}
+}
+
+// longestRun returns the length of the longest run of the same character in value.
+func longestRun(value string) int {
+	longest, run := 0, 0
+	var previous rune
+
+	for i, r := range []rune(value) {
+		if i > 0 && r == previous {
+			run++
+		} else {
+			run = 1
+		}
+
+		previous = r
+		longest = max(longest, run)
+	}
+
+	return longest
+}
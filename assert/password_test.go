@@ -0,0 +1,119 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAssertPassword(t *testing.T) {
+	type Signup struct {
+		Password string `assert:"password=default"`
+		Secret   string `assert:"password=strong"`
+	}
+
+	tests := []struct {
+		name     string
+		signup   Signup
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			signup:   Signup{Password: "correct horse battery", Secret: "Tr0ub4dor&3-Staple"},
+			expected: &[]Violation{},
+		},
+		{
+			name:   "scenario2",
+			signup: Signup{Password: "Password1", Secret: "aaab"},
+			expected: &[]Violation{
+				{Field: "Signup.Password", Constraint: "password", Code: "common_password", Message: "must not be a commonly used password"},
+				{Field: "Signup.Secret", Constraint: "password", Code: "too_short", Message: "must be at least 12 characters long"},
+				{Field: "Signup.Secret", Constraint: "password", Code: "missing_upper", Message: "must contain an uppercase letter"},
+				{Field: "Signup.Secret", Constraint: "password", Code: "missing_digit", Message: "must contain a digit"},
+				{Field: "Signup.Secret", Constraint: "password", Code: "missing_symbol", Message: "must contain a symbol"},
+				{Field: "Signup.Secret", Constraint: "password", Code: "repeated_characters", Message: "must not repeat a character more than 2 times in a row"},
+				{Field: "Signup.Secret", Constraint: "password", Code: "low_entropy", Message: "must be longer or use more kinds of characters"},
+			},
+		},
+		{
+			name:   "scenario3",
+			signup: Signup{Password: "zzzz-kirk"},
+			expected: &[]Violation{
+				{Field: "Signup.Password", Constraint: "password", Code: "repeated_characters", Message: "must not repeat a character more than 3 times in a row"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := Assert(tt.signup)
+
+			if !reflect.DeepEqual(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+
+			for _, v := range violations {
+				if tt.signup.Password != "" && strings.Contains(v.Message, tt.signup.Password) || tt.signup.Secret != "" && strings.Contains(v.Message, tt.signup.Secret) {
+					t.Errorf("Assert() violation %+v includes the value", v)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadBlocklist(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "blocklist.txt")
+
+	if err := os.WriteFile(name, []byte("# leaked\nNCC-1701\n\nenterprise\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %+v", err)
+	}
+
+	blocklist, err := LoadBlocklist(name)
+
+	if err != nil {
+		t.Fatalf("LoadBlocklist() error = %+v", err)
+	}
+
+	if expected := []string{"NCC-1701", "enterprise"}; !reflect.DeepEqual(blocklist, expected) {
+		t.Errorf("LoadBlocklist() = %+v, expected %+v", blocklist, expected)
+	}
+
+	RegisterPasswordPolicy("starfleet", PasswordPolicy{Blocklist: blocklist})
+
+	type Login struct {
+		Password string `assert:"password=starfleet"`
+	}
+
+	if violations := Assert(Login{Password: "ncc-1701"}); len(violations) != 1 || violations[0].Code != "common_password" {
+		t.Errorf("Assert() violations = %+v, expected a common_password violation", violations)
+	}
+}
+
+func init() {
+	RegisterPasswordPolicy("passphrase", PasswordPolicy{MinLength: 4, MinEntropy: 24})
+}
+
+func TestAssertPasswordRedacted(t *testing.T) {
+	type Account struct {
+		Password   string `assert:"password=default,charset=alphanumeric"`
+		Passphrase string `assert:"password=passphrase"`
+	}
+
+	account := Account{Password: "ncc 1701 enterprise", Passphrase: "密码安全很重要"}
+	violations := Assert(account)
+
+	if len(violations) != 1 || violations[0].Constraint != "charset" || violations[0].Message != "" {
+		t.Errorf("Assert() violations = %+v, expected a charset violation without a message", violations)
+	}
+
+	expected := []Violation{
+		{Field: "Account.Passphrase", Constraint: "password", Code: "too_short", Message: "must be at least 4 characters long"},
+		{Field: "Account.Passphrase", Constraint: "password", Code: "low_entropy", Message: "must be longer or use more kinds of characters"},
+	}
+
+	if violations := Assert(Account{Password: "ncc1701enterprise", Passphrase: "密码"}); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
@@ -0,0 +1,98 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// The patterns map caches the regular expressions compiled for the pattern assertions, keyed by the pattern and its
+// options.
+var patterns sync.Map
+
+// compiledPattern is the result of compiling a pattern assertion, kept whether or not it succeeded.
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// compilePattern compiles the pattern assertion with its options: flags lists the flags set among i for
+// case-insensitive, m for multiline and s to let . match \n, e.g. flags=i|m, match is full to match the whole value
+// or partial to match a substring, and engine is re2, the default, or posix for the POSIX ERE syntax with
+// leftmost-longest matching.
+func compilePattern(assertions map[string]string) (*regexp.Regexp, error) {
+	pattern := assertions["pattern"]
+	flags := assertions["pattern;flags"]
+	match := assertions["pattern;match"]
+	engine := assertions["pattern;engine"]
+	key := strings.Join([]string{pattern, flags, match, engine}, "\x00")
+
+	if c, ok := patterns.Load(key); ok {
+		return c.(compiledPattern).re, c.(compiledPattern).err
+	}
+
+	re, err := compileRegexp(pattern, flags, match, engine)
+
+	if err != nil {
+		err = fmt.Errorf("pattern: %v", err)
+	}
+
+	patterns.Store(key, compiledPattern{re: re, err: err})
+	return re, err
+}
+
+func compileRegexp(pattern string, flags string, match string, engine string) (*regexp.Regexp, error) {
+	prefix := ""
+
+	if flags != "" {
+		for _, flag := range strings.Split(flags, "|") {
+			if len(flag) != 1 || !strings.Contains("ims", flag) {
+				return nil, fmt.Errorf("unknown flag %q", flag)
+			}
+
+			prefix += flag
+		}
+	}
+
+	if match != "" && match != "partial" && match != "full" {
+		return nil, fmt.Errorf("unknown match %q", match)
+	}
+
+	switch engine {
+	case "", "re2":
+		if prefix != "" {
+			pattern = "(?" + prefix + ")" + pattern
+		}
+
+		if match == "full" {
+			pattern = `\A(?:` + pattern + `)\z`
+		}
+
+		return regexp.Compile(pattern)
+	case "posix":
+		if prefix != "" {
+			return nil, errors.New("flags aren't supported by the posix engine")
+		}
+
+		if match == "full" {
+			pattern = "^(" + pattern + ")$"
+		}
+
+		return regexp.CompilePOSIX(pattern)
+	}
+
+	return nil, fmt.Errorf("unknown engine %q", engine)
+}
+
+// assertPatternMatch applies the validator's default match to the pattern assertion before asserting it.
+func assertPatternMatch(assertions map[string]string, f field, s *scope) {
+	if _, ok := assertions["pattern;match"]; !ok && s.fullMatch {
+		assertions = maps.Clone(assertions)
+		assertions["pattern;match"] = "full"
+	}
+
+	s.violations = assertPattern(assertions, f.val, f.name, s.violations, f.path)
+}
@@ -0,0 +1,173 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAssertPatternOptions(t *testing.T) {
+	type Ship struct {
+		Registry string `assert:"pattern=ncc-\\d+;flags=i"`
+		Class    string `assert:"pattern=[A-Z][a-z]+;match=full"`
+		Log      string `assert:"pattern=^Captain;flags=m"`
+		Prefix   string `assert:"pattern=NCC|NX;engine=posix;match=full"`
+		Name     string `assert:"pattern=(Enterprise"`
+	}
+
+	tests := []struct {
+		name     string
+		ship     Ship
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			ship:     Ship{Registry: "NCC-1701", Class: "Constitution", Log: "Stardate 1513.1\nCaptain's log", Prefix: "NX"},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			ship: Ship{Registry: "USS", Class: "Constitution class", Log: "Stardate 1513.1", Prefix: "NCCX"},
+			expected: &[]Violation{
+				{Field: "Ship.Registry", Constraint: "pattern"},
+				{Field: "Ship.Class", Constraint: "pattern"},
+				{Field: "Ship.Log", Constraint: "pattern"},
+				{Field: "Ship.Prefix", Constraint: "pattern"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.ship); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertPatternSemicolon(t *testing.T) {
+	type Entry struct {
+		Title  string `assert:"pattern=^[^;]+$"`
+		Author string `assert:"pattern=^[a-z]+\\;by=[a-z]+$;flags=i"`
+	}
+
+	if violations := Assert(Entry{Title: "Stardate 1513.1", Author: "Log;by=Kirk"}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{{Field: "Entry.Title", Constraint: "pattern"}, {Field: "Entry.Author", Constraint: "pattern"}}
+
+	if violations := Assert(Entry{Title: "Captain; log", Author: "Kirk"}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
+
+func TestAssertPatternComma(t *testing.T) {
+	type Registry struct {
+		Prefix string `assert:"pattern=^\\d{1\\,3}$,maxlength=3"`
+	}
+
+	if violations := Assert(Registry{Prefix: "74"}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{{Field: "Registry.Prefix", Constraint: "pattern"}, {Field: "Registry.Prefix", Constraint: "maxlength"}}
+
+	if violations := Assert(Registry{Prefix: "1701"}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+
+	if err := CheckTags(Registry{}); err != nil {
+		t.Errorf("CheckTags() error = %v, expected none", err)
+	}
+}
+
+func TestFullMatch(t *testing.T) {
+	type Heading struct {
+		Direction string `assert:"pattern=N|S"`
+		Bearing   string `assert:"pattern=\\d+;match=partial"`
+	}
+
+	heading := Heading{Direction: "NE", Bearing: "045 degrees"}
+
+	if violations := Assert(heading); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{{Field: "Heading.Direction", Constraint: "pattern"}}
+
+	if violations := New(Options{FullMatch: true}).Assert(heading); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
+
+func TestCheckTags(t *testing.T) {
+	type Badge struct {
+		Code string `assert:"pattern=[0-9"`
+	}
+
+	type Officer struct {
+		Name   string   `assert:"required=true,maxlenght=20"`
+		Rank   string   `assert:"pattern=^[A-Z];flags=x"`
+		Badges []*Badge `assert:"required"`
+	}
+
+	if err := CheckTags(Person{}); err != nil {
+		t.Errorf("CheckTags() error = %+v, expected nil", err)
+	}
+
+	err := CheckTags(&Officer{})
+
+	expected := []string{
+		"assert: invalid maxlenght tag on Officer.Name: unknown constraint",
+		`assert: invalid pattern tag on Officer.Rank: pattern: unknown flag "x"`,
+		"assert: invalid required tag on Officer.Badges: pair doesn't contain both a key and value",
+		"assert: invalid pattern tag on Officer.Badge.Code: pattern: error parsing regexp: missing closing ]: `[0-9`",
+	}
+
+	for _, message := range expected {
+		found := false
+
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			found = found || e.Error() == message
+		}
+
+		if !found {
+			t.Errorf("CheckTags() error = %v, expected %s", err, message)
+		}
+	}
+
+	var tagErr *TagError
+	if !errors.As(err, &tagErr) {
+		t.Errorf("CheckTags() error = %v, expected a TagError", err)
+	}
+}
+
+func TestCheckTypeTags(t *testing.T) {
+	type Shuttle struct {
+		Name string `assert:"pattern=(Galileo"`
+	}
+
+	checkedTypes.Delete(reflect.TypeOf(Shuttle{}))
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	for i := 0; i < 2; i++ {
+		if violations := Assert(Shuttle{Name: "Galileo"}); len(violations) != 0 {
+			t.Errorf("Assert() violations = %+v, expected none", violations)
+		}
+	}
+
+	message := "assert: invalid pattern tag on Shuttle.Name: pattern: error parsing regexp: missing closing ): `(Galileo`"
+
+	if count := strings.Count(buf.String(), message); count != 1 {
+		t.Errorf("Assert() logged %q, expected %s once", buf.String(), message)
+	}
+}
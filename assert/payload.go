@@ -0,0 +1,344 @@
+package assert
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// parseJSON accepts a JSON document.
+func parseJSON(value string, assertions map[string]string) error {
+	var v interface{}
+
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return fmt.Errorf("json: %v", err)
+	}
+
+	return nil
+}
+
+// parseBase64 accepts standard base64 with padding.
+func parseBase64(value string, assertions map[string]string) error {
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		return fmt.Errorf("base64: %v", err)
+	}
+
+	return nil
+}
+
+// parseBase64URL accepts URL-safe base64 with or without padding.
+func parseBase64URL(value string, assertions map[string]string) error {
+	encoding := base64.URLEncoding
+
+	if len(value)%4 != 0 && !strings.HasSuffix(value, "=") {
+		encoding = base64.RawURLEncoding
+	}
+
+	if _, err := encoding.DecodeString(value); err != nil {
+		return fmt.Errorf("base64url: %v", err)
+	}
+
+	return nil
+}
+
+// parseHex accepts an even number of hexadecimal digits in either case.
+func parseHex(value string, assertions map[string]string) error {
+	if _, err := hex.DecodeString(value); err != nil {
+		return fmt.Errorf("hex: %s", strings.TrimPrefix(err.Error(), "encoding/hex: "))
+	}
+
+	return nil
+}
+
+// parseRegexp accepts a regular expression in the RE2 syntax used by the regexp package.
+func parseRegexp(value string, assertions map[string]string) error {
+	if _, err := regexp.Compile(value); err != nil {
+		return fmt.Errorf("regexp: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+
+	return nil
+}
+
+// parseTemplate accepts a Go text/template.
+func parseTemplate(value string, assertions map[string]string) error {
+	// the errors of an unnamed template start with template: :line:
+	if _, err := template.New("").Parse(value); err != nil {
+		return fmt.Errorf("template: line %s", strings.TrimPrefix(err.Error(), "template: :"))
+	}
+
+	return nil
+}
+
+// semver is a semantic version as defined by Semantic Versioning 2.0.0. The build metadata is ignored.
+type semver struct {
+	major, minor, patch int
+	prerelease          []string
+}
+
+// semverPattern matches a semantic version, capturing the major, minor and patch versions and the pre-release.
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?$`)
+
+// parseSemver accepts a semantic version, e.g. 1.2.3-rc.1+build.5. The range option restricts the versions accepted,
+// e.g. format=semver;range=>=1.2.0 <2.0.0. A range is a list of comparisons that must all hold, each an operator among
+// =, >, >=, < and <= followed by a version, or a caret or tilde range, e.g. ^1.2.0 or ~1.2.0. Ranges separated by ||
+// are alternatives.
+func parseSemver(value string, assertions map[string]string) error {
+	v, err := asSemver(value)
+
+	if err != nil {
+		return err
+	}
+
+	if r, ok := assertions["format;range"]; ok {
+		within, err := v.within(r)
+
+		if err != nil {
+			return err
+		}
+
+		if !within {
+			return fmt.Errorf("semver: %s is not within %s", value, r)
+		}
+	}
+
+	return nil
+}
+
+func asSemver(value string) (semver, error) {
+	m := semverPattern.FindStringSubmatch(value)
+
+	if m == nil {
+		return semver{}, fmt.Errorf("semver: invalid semantic version %q", value)
+	}
+
+	v := semver{}
+	v.major, _ = strconv.Atoi(m[1])
+	v.minor, _ = strconv.Atoi(m[2])
+	v.patch, _ = strconv.Atoi(m[3])
+
+	if m[4] != "" {
+		v.prerelease = strings.Split(m[4], ".")
+	}
+
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 as v precedes, equals or follows w. A pre-release precedes its release, and pre-release
+// identifiers are compared numerically when both are numbers.
+func (v semver) compare(w semver) int {
+	for _, c := range [][2]int{{v.major, w.major}, {v.minor, w.minor}, {v.patch, w.patch}} {
+		if c[0] != c[1] {
+			return cmp.Compare(c[0], c[1])
+		}
+	}
+
+	switch {
+	case len(v.prerelease) == 0 && len(w.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(w.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(w.prerelease); i++ {
+		a, b := v.prerelease[i], w.prerelease[i]
+		m, errA := strconv.Atoi(a)
+		n, errB := strconv.Atoi(b)
+
+		switch {
+		case errA == nil && errB == nil && m != n:
+			return cmp.Compare(m, n)
+		case errA == nil && errB != nil:
+			return -1
+		case errA != nil && errB == nil:
+			return 1
+		case a != b:
+			return strings.Compare(a, b)
+		}
+	}
+
+	return cmp.Compare(len(v.prerelease), len(w.prerelease))
+}
+
+// within returns true if v satisfies the range.
+func (v semver) within(r string) (bool, error) {
+	for _, alternative := range strings.Split(r, "||") {
+		fields := strings.Fields(alternative)
+
+		if len(fields) == 0 {
+			return false, fmt.Errorf("semver: invalid range %q", r)
+		}
+
+		all := true
+
+		for _, comparison := range fields {
+			ok, err := v.satisfies(comparison)
+
+			if err != nil {
+				return false, fmt.Errorf("semver: invalid range %q: %v", r, err)
+			}
+
+			all = all && ok
+		}
+
+		if all {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// satisfies returns true if v satisfies a single comparison, e.g. >=1.2.0 or ^1.2.0.
+func (v semver) satisfies(comparison string) (bool, error) {
+	version := strings.TrimLeft(comparison, "<>=^~")
+	operator := comparison[:len(comparison)-len(version)]
+	w, err := asSemver(version)
+
+	if err != nil {
+		return false, errors.New(strings.TrimPrefix(err.Error(), "semver: "))
+	}
+
+	c := v.compare(w)
+
+	switch operator {
+	case "", "=":
+		return c == 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case "^":
+		// the caret allows the changes that don't modify the left-most non-zero component
+		upper := semver{major: w.major + 1}
+		if w.major == 0 && w.minor == 0 {
+			upper = semver{patch: w.patch + 1}
+		} else if w.major == 0 {
+			upper = semver{minor: w.minor + 1}
+		}
+		return c >= 0 && v.compare(upper) < 0, nil
+	case "~":
+		return c >= 0 && v.compare(semver{major: w.major, minor: w.minor + 1}) < 0, nil
+	}
+
+	return false, fmt.Errorf("unknown operator %q", operator)
+}
+
+// cronField describes a field of a cron expression: its name, the range of its values and the names that can be used
+// instead of the numbers, starting from the lowest value.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+// The cronFields slice contains the fields of a cron expression in order. Sunday is 0 or 7 in the day of the week.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// The cronMacros slice contains the macros that can replace the five fields of a cron expression.
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// parseCron accepts a cron expression of five fields, e.g. */15 9-17 * * MON-FRI, or a macro, e.g. @daily. Each field
+// is a list of values, ranges or * separated by commas, each optionally followed by a step, e.g. 0-30/5.
+func parseCron(value string, assertions map[string]string) error {
+	if strings.HasPrefix(value, "@") {
+		if !containsString(cronMacros, value) {
+			return fmt.Errorf("cron: unknown macro %q", value)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(value)
+
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("cron: expected %d fields, found %d", len(cronFields), len(fields))
+	}
+
+	for i, f := range fields {
+		if err := cronFields[i].parse(f); err != nil {
+			return fmt.Errorf("cron: %s: %v", cronFields[i].name, err)
+		}
+	}
+
+	return nil
+}
+
+// parse checks the list of values, ranges or * of the field.
+func (c cronField) parse(list string) error {
+	for _, item := range strings.Split(list, ",") {
+		item, step, stepped := strings.Cut(item, "/")
+
+		if stepped {
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+
+		if item == "*" {
+			continue
+		}
+
+		from, to, isRange := strings.Cut(item, "-")
+
+		low, err := c.value(from)
+		if err != nil {
+			return err
+		}
+
+		if !isRange {
+			continue
+		}
+
+		high, err := c.value(to)
+		if err != nil {
+			return err
+		}
+
+		if low > high {
+			return fmt.Errorf("invalid range %q", item)
+		}
+	}
+
+	return nil
+}
+
+// value returns the number of a value of the field, given as a number or a name.
+func (c cronField) value(v string) (int, error) {
+	for i, name := range c.names {
+		if strings.EqualFold(v, name) {
+			return c.min + i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(v)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", v)
+	}
+
+	if n < c.min || n > c.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, c.min, c.max)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,105 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertPayloads(t *testing.T) {
+	type Config struct {
+		Settings string `assert:"format=json"`
+		Key      string `assert:"format=base64"`
+		Token    string `assert:"format=base64url"`
+		Digest   string `assert:"format=hex"`
+		Filter   string `assert:"format=regexp"`
+		Version  string `assert:"format=semver;range=>=1.2.0 <2.0.0 || ^3.1.0"`
+		Schedule string `assert:"format=cron"`
+		Greeting string `assert:"format=template"`
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			config: Config{
+				Settings: `{"warp":9}`,
+				Key:      "a2lyaw==",
+				Token:    "a2lyaw",
+				Digest:   "0aFF",
+				Filter:   `^NCC-\d+$`,
+				Version:  "1.9.0-rc.1+build.5",
+				Schedule: "*/15 9-17 * JAN-DEC mon-fri",
+				Greeting: "Hello, {{.Name}}",
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			config:   Config{Version: "3.4.1", Schedule: "@daily"},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario3",
+			config: Config{
+				Settings: `{"warp":}`,
+				Key:      "a2lyaw",
+				Token:    "a2l+aw",
+				Digest:   "abc",
+				Filter:   `(NCC`,
+				Version:  "1.2.0-rc.1",
+				Schedule: "0 24 * * *",
+				Greeting: "Hello, {{.Name}",
+			},
+			expected: &[]Violation{
+				{Field: "Config.Settings", Constraint: "format", Message: "json: invalid character '}' looking for beginning of value"},
+				{Field: "Config.Key", Constraint: "format", Message: "base64: illegal base64 data at input byte 4"},
+				{Field: "Config.Token", Constraint: "format", Message: "base64url: illegal base64 data at input byte 3"},
+				{Field: "Config.Digest", Constraint: "format", Message: "hex: odd length hex string"},
+				{Field: "Config.Filter", Constraint: "format", Message: "regexp: missing closing ): `(NCC`"},
+				{Field: "Config.Version", Constraint: "format", Message: "semver: 1.2.0-rc.1 is not within >=1.2.0 <2.0.0 || ^3.1.0"},
+				{Field: "Config.Schedule", Constraint: "format", Message: "cron: hour: value 24 out of range 0-23"},
+				{Field: "Config.Greeting", Constraint: "format", Message: `template: line 1: bad character U+007D '}'`},
+			},
+		},
+		{
+			name:   "scenario4",
+			config: Config{Version: "v1.2", Schedule: "0 9 * *"},
+			expected: &[]Violation{
+				{Field: "Config.Version", Constraint: "format", Message: `semver: invalid semantic version "v1.2"`},
+				{Field: "Config.Schedule", Constraint: "format", Message: "cron: expected 5 fields, found 4"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.config); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertSemverCaret(t *testing.T) {
+	type Dependency struct {
+		Patch string `assert:"format=semver;range=^0.0.3"`
+		Minor string `assert:"format=semver;range=^0.2.3"`
+		Major string `assert:"format=semver;range=^1.2.3"`
+	}
+
+	if violations := Assert(Dependency{Patch: "0.0.3", Minor: "0.2.9", Major: "1.9.0"}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+
+	expected := []Violation{
+		{Field: "Dependency.Patch", Constraint: "format", Message: "semver: 0.0.4 is not within ^0.0.3"},
+		{Field: "Dependency.Minor", Constraint: "format", Message: "semver: 0.3.0 is not within ^0.2.3"},
+		{Field: "Dependency.Major", Constraint: "format", Message: "semver: 2.0.0 is not within ^1.2.3"},
+	}
+
+	if violations := Assert(Dependency{Patch: "0.0.4", Minor: "0.3.0", Major: "2.0.0"}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}
@@ -0,0 +1,277 @@
+package assert
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/phones.tsv
+var phonesTSV string
+
+// e164 matches a phone number in the E.164 format: a plus sign and up to 15 digits, the first of which isn't 0.
+var e164 = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// phonePlan is the numbering plan of a country: the calling code, the trunk prefix dialled before national numbers,
+// and the lengths and prefixes of the national significant numbers.
+type phonePlan struct {
+	country  string
+	code     string
+	trunk    string
+	lengths  []span
+	prefixes []span
+}
+
+// span is an inclusive range of numbers, e.g. 2-9. A prefix span matches the leading digits of a number.
+type span struct {
+	from, to int
+	digits   int
+}
+
+// The phonePlans map contains the numbering plans associated with the ISO 3166-1 alpha-2 country codes.
+var phonePlans = loadPhonePlans(phonesTSV)
+
+// The unplannedCodes map contains the calling codes shared by a country without a numbering plan, whose numbers can't
+// be rejected by the plans of the other countries.
+var unplannedCodes = loadUnplannedCodes()
+
+// loadPhonePlans parses the embedded table of numbering plans.
+func loadPhonePlans(table string) map[string]*phonePlan {
+	loaded := make(map[string]*phonePlan)
+
+	for _, columns := range readTSV(table) {
+		plan := &phonePlan{
+			country:  columns[0],
+			code:     columns[1],
+			lengths:  parseSpans(columns[3]),
+			prefixes: parseSpans(columns[4]),
+		}
+
+		if columns[2] != "-" {
+			plan.trunk = columns[2]
+		}
+
+		loaded[plan.country] = plan
+	}
+
+	return loaded
+}
+
+// loadUnplannedCodes returns the calling codes of the countries without a numbering plan.
+func loadUnplannedCodes() map[string]bool {
+	loaded := make(map[string]bool)
+
+	for key, c := range countries {
+		if key != c.alpha2 || c.callingCode == "" {
+			continue
+		}
+
+		if _, ok := phonePlans[c.alpha2]; !ok {
+			loaded[c.callingCode] = true
+		}
+	}
+
+	return loaded
+}
+
+// parseSpans parses a list of numbers and ranges separated by |, e.g. 2-5|8.
+func parseSpans(list string) []span {
+	spans := make([]span, 0)
+
+	for _, item := range strings.Split(list, "|") {
+		from, to, isRange := strings.Cut(item, "-")
+
+		if !isRange {
+			to = from
+		}
+
+		f, _ := strconv.Atoi(from)
+		t, _ := strconv.Atoi(to)
+		spans = append(spans, span{from: f, to: t, digits: len(from)})
+	}
+
+	return spans
+}
+
+// matchesLength returns true if n is within one of the spans.
+func matchesLength(spans []span, n int) bool {
+	for _, s := range spans {
+		if n >= s.from && n <= s.to {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPrefix returns true if the leading digits of number are within one of the spans.
+func matchesPrefix(spans []span, number string) bool {
+	for _, s := range spans {
+		if len(number) < s.digits {
+			continue
+		}
+
+		if n, _ := strconv.Atoi(number[:s.digits]); n >= s.from && n <= s.to {
+			return true
+		}
+	}
+	return false
+}
+
+// isValid returns true if the national significant number has one of the lengths and prefixes of the plan. Any
+// number is valid for a plan without lengths, which only has a calling code.
+func (p *phonePlan) isValid(national string) bool {
+	if p.lengths == nil {
+		return true
+	}
+
+	return matchesLength(p.lengths, len(national)) && matchesPrefix(p.prefixes, national)
+}
+
+// assertPhone checks that the field value, a string, is a phone number. With phone=e164 the value is in the E.164
+// format, e.g. +14155552671, and the number must be valid for its calling code when the code is in the embedded
+// metadata. Otherwise the assertion references the field holding the country, e.g. phone=Country, and the value is a
+// number of that country in the E.164 format or in the national format, e.g. (415) 555-2671. A country without
+// metadata only accepts the E.164 format with its calling code. With the normalize option, e.g.
+// phone=Country;normalize=true, a valid value is replaced by its E.164 form once the field's other constraints have
+// been asserted, which requires passing a pointer to the struct.
+func assertPhone(assertions map[string]string, f field, s *scope) {
+	value, ok := asStringValue(f.val)
+
+	if !ok || value == "" {
+		return
+	}
+
+	ref := assertions["phone"]
+	number := ""
+
+	if ref == "e164" {
+		if !e164.MatchString(value) {
+			s.reportCode(f, "phone", "invalid_format", "must be an E.164 phone number, e.g. +14155552671")
+			return
+		}
+
+		if code, ok := checkCallingCode(value[1:]); !ok {
+			s.reportCode(f, "phone", "invalid_number", fmt.Sprintf("must be a valid phone number of country code +%s", code))
+			return
+		}
+
+		number = value
+	} else {
+		v, found := s.lookup(ref)
+
+		if !found {
+			log.Printf("unknown field %s referenced by phone validation", ref)
+			return
+		}
+
+		code, _ := asStringValue(v)
+		c, known := lookupCountry(code)
+
+		// an unknown country is left to the country assertion
+		if !known {
+			return
+		}
+
+		plan, ok := phonePlans[c.alpha2]
+
+		// without a numbering plan only the calling code of a number in the E.164 format can be checked
+		if !ok {
+			if c.callingCode == "" {
+				s.reportCode(f, "phone", "invalid_number", fmt.Sprintf("must be a valid phone number of %s", c.name))
+				return
+			}
+
+			plan = &phonePlan{country: c.alpha2, code: c.callingCode}
+		}
+
+		n, err := plan.asE164(value)
+
+		if err != nil {
+			s.reportCode(f, "phone", "invalid_format", err.Error())
+			return
+		}
+
+		if !plan.isValid(n[len(plan.code)+1:]) {
+			s.reportCode(f, "phone", "invalid_number", fmt.Sprintf("must be a valid phone number of %s", c.name))
+			return
+		}
+
+		number = n
+	}
+
+	// the value is replaced once the field's other constraints have been asserted against it
+	if assertions["phone;normalize"] == "true" {
+		if val := indirect(f.val); val.Kind() == reflect.String && val.CanSet() {
+			s.normalizations = append(s.normalizations, func() { val.SetString(number) })
+		}
+	}
+}
+
+// checkCallingCode returns false if the digits start with a calling code in the embedded metadata and the rest of
+// them isn't a valid number of any of the countries sharing the code, which is also returned. A code shared by a
+// country without a numbering plan is never rejected.
+func checkCallingCode(digits string) (string, bool) {
+	code := ""
+	valid := true
+
+	for _, plan := range phonePlans {
+		if !strings.HasPrefix(digits, plan.code) {
+			continue
+		}
+
+		if plan.isValid(digits[len(plan.code):]) {
+			return plan.code, true
+		}
+
+		code, valid = plan.code, unplannedCodes[plan.code]
+	}
+
+	return code, valid
+}
+
+// asE164 converts a number of the country in the E.164 format or in the national format to the E.164 format. Spaces,
+// hyphens, dots and parentheses between the digits are ignored.
+func (p *phonePlan) asE164(value string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" -.()", r) {
+			return -1
+		}
+		return r
+	}, value)
+
+	if international, ok := strings.CutPrefix(digits, "+"); ok {
+		if !e164.MatchString(digits) {
+			return "", errors.New("must be an E.164 phone number")
+		}
+
+		if !strings.HasPrefix(international, p.code) {
+			return "", fmt.Errorf("must have the country code +%s", p.code)
+		}
+
+		return digits, nil
+	}
+
+	// the national format of a country is only known from its numbering plan
+	if p.lengths == nil {
+		return "", errors.New("must be an E.164 phone number")
+	}
+
+	if !isDigits(digits) {
+		return "", errors.New("must be a phone number in the E.164 or national format")
+	}
+
+	if p.trunk != "" {
+		digits = strings.TrimPrefix(digits, p.trunk)
+	}
+
+	if digits == "" || !e164.MatchString("+"+p.code+digits) {
+		return "", errors.New("must be a phone number in the E.164 or national format")
+	}
+
+	return "+" + p.code + digits, nil
+}
@@ -0,0 +1,130 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertPhone(t *testing.T) {
+	type Customer struct {
+		Country string
+		Mobile  string `assert:"phone=e164"`
+		Home    string `assert:"phone=Country"`
+		Work    string `assert:"phone=Country;normalize=true"`
+	}
+
+	tests := []struct {
+		name     string
+		customer Customer
+		expected *[]Violation
+		work     string
+	}{
+		{
+			name:     "scenario1",
+			customer: Customer{Country: "US", Mobile: "+14155552671", Home: "(415) 555-2671", Work: "1-415-555-2671"},
+			expected: &[]Violation{},
+			work:     "+14155552671",
+		},
+		{
+			name:     "scenario2",
+			customer: Customer{Country: "GBR", Mobile: "+442079460958", Home: "+44 20 7946 0958", Work: "020 7946 0958"},
+			expected: &[]Violation{},
+			work:     "+442079460958",
+		},
+		{
+			name:     "scenario3",
+			customer: Customer{Country: "FR", Mobile: "+8613800138000", Home: "01 23 45 67 89"},
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario4",
+			customer: Customer{Country: "US", Mobile: "4155552671", Home: "+44 20 7946 0958", Work: "555-2671"},
+			expected: &[]Violation{
+				{Field: "Customer.Mobile", Constraint: "phone", Code: "invalid_format", Message: "must be an E.164 phone number, e.g. +14155552671"},
+				{Field: "Customer.Home", Constraint: "phone", Code: "invalid_format", Message: "must have the country code +1"},
+				{Field: "Customer.Work", Constraint: "phone", Code: "invalid_number", Message: "must be a valid phone number of United States"},
+			},
+			work: "555-2671",
+		},
+		{
+			name:     "scenario5",
+			customer: Customer{Country: "DE", Mobile: "+1055552671", Home: "030 1234 56x"},
+			expected: &[]Violation{
+				{Field: "Customer.Mobile", Constraint: "phone", Code: "invalid_number", Message: "must be a valid phone number of country code +1"},
+				{Field: "Customer.Home", Constraint: "phone", Code: "invalid_format", Message: "must be a phone number in the E.164 or national format"},
+			},
+		},
+		{
+			name:     "scenario6",
+			customer: Customer{Country: "IS", Home: "+354 551 2345", Work: "+354-551-2345"},
+			expected: &[]Violation{},
+			work:     "+3545512345",
+		},
+		{
+			name:     "scenario7",
+			customer: Customer{Country: "IS", Home: "not a phone", Work: "+1 415 555 2671"},
+			expected: &[]Violation{
+				{Field: "Customer.Home", Constraint: "phone", Code: "invalid_format", Message: "must be an E.164 phone number"},
+				{Field: "Customer.Work", Constraint: "phone", Code: "invalid_format", Message: "must have the country code +354"},
+			},
+			work: "+1 415 555 2671",
+		},
+		{
+			name:     "scenario8",
+			customer: Customer{Country: "BV", Home: "+47 2212 3456"},
+			expected: &[]Violation{
+				{Field: "Customer.Home", Constraint: "phone", Code: "invalid_number", Message: "must be a valid phone number of Bouvet Island"},
+			},
+		},
+		{
+			name:     "scenario9",
+			customer: Customer{Country: "KZ", Mobile: "+77012345678", Home: "+7 495 123 4567", Work: "8 701 234 5678"},
+			expected: &[]Violation{
+				{Field: "Customer.Home", Constraint: "phone", Code: "invalid_number", Message: "must be a valid phone number of Kazakhstan"},
+			},
+			work: "+77012345678",
+		},
+		{
+			name:     "scenario10",
+			customer: Customer{Country: "DO", Mobile: "+18095551234", Home: "+1 415 555 2671", Work: "1 (829) 555-1234"},
+			expected: &[]Violation{
+				{Field: "Customer.Home", Constraint: "phone", Code: "invalid_number", Message: "must be a valid phone number of Dominican Republic"},
+			},
+			work: "+18295551234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			customer := tt.customer
+
+			if violations := Assert(&customer); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+
+			if customer.Work != tt.work {
+				t.Errorf("Assert() Work = %s, expected %s", customer.Work, tt.work)
+			}
+		})
+	}
+}
+
+func TestAssertPhoneNormalize(t *testing.T) {
+	type Contact struct {
+		Country string
+		Phone   string `assert:"phone=Country;normalize=true,pattern=^\\(,maxlength=12"`
+	}
+
+	expected := []Violation{{Field: "Contact.Phone", Constraint: "maxlength"}}
+
+	for i := 0; i < 20; i++ {
+		contact := Contact{Country: "US", Phone: "(415) 555-2671"}
+
+		if violations := Assert(&contact); !sameViolations(violations, expected) {
+			t.Fatalf("Assert() violations = %+v, expected %+v", violations, expected)
+		}
+
+		if contact.Phone != "+14155552671" {
+			t.Fatalf("Assert() Phone = %s, expected +14155552671", contact.Phone)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertPointers(t *testing.T) {
+	type Reading struct {
+		Temperature *float64  `assert:"min=-40.0,max=60.0"`
+		Humidity    *int      `assert:"min=0,max=100"`
+		Station     **string  `assert:"maxlength=4,pattern=^[A-Z]+$"`
+		Note        *string   `assert:"minlength=3,oneof=ok|check"`
+		Unit        *string   `assert:"required=true,oneof=C|F"`
+		Source      *string   `assert:"nilable=false,format=url"`
+		Tags        *[]string `assert:"nilable=false"`
+	}
+
+	temperature, humidity, station, unit, source := 72.5, 101, "KMEMX", "K", "ftp://example.com"
+	stationPtr := &station
+	tags := []string{}
+
+	tests := []struct {
+		name     string
+		reading  Reading
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			reading: Reading{
+				Temperature: &temperature,
+				Humidity:    &humidity,
+				Station:     &stationPtr,
+				Unit:        &unit,
+				Source:      &source,
+				Tags:        &tags,
+			},
+			expected: &[]Violation{
+				{Field: "Reading.Temperature", Constraint: "max"},
+				{Field: "Reading.Humidity", Constraint: "max"},
+				{Field: "Reading.Station", Constraint: "maxlength"},
+				{Field: "Reading.Unit", Constraint: "oneof", Message: `must be one of C, F; did you mean "C"?`},
+			},
+		},
+		{
+			name:    "scenario2",
+			reading: Reading{Station: new(*string)},
+			expected: &[]Violation{
+				{Field: "Reading.Unit", Constraint: "required"},
+				{Field: "Reading.Source", Constraint: "nilable", Message: "must not be nil"},
+				{Field: "Reading.Tags", Constraint: "nilable", Message: "must not be nil"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.reading); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
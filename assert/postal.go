@@ -0,0 +1,46 @@
+package assert
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// genericPostal is the format accepted for the countries with postal codes in an unknown format.
+var genericPostal = regexp.MustCompile(`^(?i:[A-Z0-9][A-Z0-9 \-]{1,9})$`)
+
+// assertPostalCode checks that the field value, a string, is a postal code of the country held by the referenced
+// field, e.g. postalcode=Country. The country is an ISO 3166-1 alpha-2, alpha-3 or numeric code. Any value is
+// accepted for countries without postal codes, and values of countries with an unknown postal code format only have
+// to look like a postal code. An unknown country is left to the country assertion.
+func assertPostalCode(assertions map[string]string, f field, s *scope) {
+	ref := assertions["postalcode"]
+	v, found := s.lookup(ref)
+
+	if !found {
+		log.Printf("unknown field %s referenced by postalcode validation", ref)
+		return
+	}
+
+	value, ok := asStringValue(f.val)
+	code, known := asStringValue(v)
+
+	if !ok || value == "" || !known {
+		return
+	}
+
+	c, known := lookupCountry(code)
+
+	if !known || !c.hasPostal {
+		return
+	}
+
+	postal := c.postal
+	if postal == nil {
+		postal = genericPostal
+	}
+
+	if !postal.MatchString(value) {
+		s.reportMessage(f, "postalcode", fmt.Sprintf("must be a postal code of %s", c.name))
+	}
+}
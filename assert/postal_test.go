@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestAssertPostalCode(t *testing.T) {
+	type Address struct {
+		Country string `assert:"required=true"`
+		ZipCode string `assert:"postalcode=Country"`
+	}
+
+	tests := []struct {
+		name     string
+		address  Address
+		expected *[]Violation
+	}{
+		{name: "scenario1", address: Address{Country: "USA", ZipCode: "38107"}, expected: &[]Violation{}},
+		{name: "scenario2", address: Address{Country: "US", ZipCode: "38107-1234"}, expected: &[]Violation{}},
+		{name: "scenario3", address: Address{Country: "ca", ZipCode: "k1a 0b1"}, expected: &[]Violation{}},
+		{name: "scenario4", address: Address{Country: "826", ZipCode: "SW1A 1AA"}, expected: &[]Violation{}},
+		{name: "scenario5", address: Address{Country: "AE", ZipCode: "anything"}, expected: &[]Violation{}},
+		{name: "scenario6", address: Address{Country: "JM", ZipCode: "JMAKN01"}, expected: &[]Violation{}},
+		{name: "scenario7", address: Address{Country: "ZZ", ZipCode: "38107"}, expected: &[]Violation{}},
+		{name: "scenario8", address: Address{Country: "USA"}, expected: &[]Violation{}},
+		{
+			name:     "scenario9",
+			address:  Address{Country: "USA", ZipCode: "3810"},
+			expected: &[]Violation{{Field: "Address.ZipCode", Constraint: "postalcode", Message: "must be a postal code of United States"}},
+		},
+		{
+			name:     "scenario10",
+			address:  Address{Country: "NLD", ZipCode: "1234"},
+			expected: &[]Violation{{Field: "Address.ZipCode", Constraint: "postalcode", Message: "must be a postal code of Netherlands"}},
+		},
+		{
+			name:     "scenario11",
+			address:  Address{Country: "JM", ZipCode: "#1"},
+			expected: &[]Violation{{Field: "Address.ZipCode", Constraint: "postalcode", Message: "must be a postal code of Jamaica"}},
+		},
+		{name: "scenario12", address: Address{Country: "IE", ZipCode: "A65 F4E2"}, expected: &[]Violation{}},
+		{name: "scenario13", address: Address{Country: "IRL", ZipCode: "D02X285"}, expected: &[]Violation{}},
+		{name: "scenario14", address: Address{Country: "IE", ZipCode: "D6W 1A2C"}, expected: &[]Violation{}},
+		{
+			name:     "scenario15",
+			address:  Address{Country: "IE", ZipCode: "A65"},
+			expected: &[]Violation{{Field: "Address.ZipCode", Constraint: "postalcode", Message: "must be a postal code of Ireland"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.address); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadCountries(t *testing.T) {
+	if len(countries) != 3*249 {
+		t.Errorf("len(countries) = %d, expected %d", len(countries), 3*249)
+	}
+
+	for code, c := range countries {
+		if code != c.alpha2 && code != c.alpha3 && code != c.numeric {
+			t.Errorf("countries[%s] = %+v", code, c)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Presence records which fields of a struct were present in a decoded JSON document. Fields are keyed by their Go
+// field name so that the presence information can be walked alongside the struct.
+type Presence struct {
+	null   bool
+	fields map[string]*Presence
+	elems  []*Presence
+}
+
+// DecodeJSON decodes the JSON document data into v, which must be a pointer to a struct, and returns the fields that
+// were present in the document. The returned Presence is used with AssertPresence.
+func DecodeJSON(data []byte, v interface{}) (*Presence, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return asPresence(doc, reflect.TypeOf(v)), nil
+}
+
+// Paths returns the paths of the fields present in the document, using the same syntax as Violation.Field. The
+// type t of the decoded struct qualifies the paths. The result can be passed to AssertPartial.
+func (p *Presence) Paths(t reflect.Type) []string {
+	found := make([]string, 0)
+	p.paths(t, "", &found)
+
+	// elements of a slice share the same paths
+	seen := make(map[string]bool)
+	paths := make([]string, 0, len(found))
+
+	for _, path := range found {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+func (p *Presence) paths(t reflect.Type, path string, paths *[]string) {
+	if p == nil {
+		return
+	}
+
+	t = indirectType(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		path = asPath(path, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+
+			if fp, ok := p.fields[f.Name]; ok {
+				*paths = append(*paths, asQualifiedPath(path, f.Name))
+				fp.paths(f.Type, path, paths)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for _, el := range p.elems {
+			el.paths(t.Elem(), path, paths)
+		}
+	}
+}
+
+// field returns the presence of the named field, or nil if the field was absent.
+func (p *Presence) field(name string) *Presence {
+	if p == nil {
+		return nil
+	}
+	return p.fields[name]
+}
+
+// elem returns the presence of the element at index i, or nil if the element was absent.
+func (p *Presence) elem(i int) *Presence {
+	if p == nil || i >= len(p.elems) {
+		return nil
+	}
+	return p.elems[i]
+}
+
+// asPresence builds the presence of the decoded document doc against the type t.
+func asPresence(doc interface{}, t reflect.Type) *Presence {
+	t = indirectType(t)
+	p := &Presence{}
+
+	switch d := doc.(type) {
+	case nil:
+		p.null = true
+	case map[string]interface{}:
+		if t.Kind() == reflect.Struct {
+			p.fields = asFieldPresence(d, t)
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, el := range d {
+				p.elems = append(p.elems, asPresence(el, t.Elem()))
+			}
+		}
+	}
+
+	return p
+}
+
+// asFieldPresence matches the keys of the JSON object obj to the fields of the struct type t the same way
+// encoding/json does.
+func asFieldPresence(obj map[string]interface{}, t reflect.Type) map[string]*Presence {
+	fields := make(map[string]*Presence)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, named := asJSONName(f)
+
+		if name == "-" {
+			continue
+		}
+
+		// embedded structs have their fields promoted into the enclosing object
+		if f.Anonymous && !named && indirectType(f.Type).Kind() == reflect.Struct {
+			fields[f.Name] = &Presence{fields: asFieldPresence(obj, indirectType(f.Type))}
+			continue
+		}
+
+		if value, ok := obj[name]; ok {
+			fields[f.Name] = asPresence(value, f.Type)
+			continue
+		}
+
+		for key, value := range obj {
+			if strings.EqualFold(key, name) {
+				fields[f.Name] = asPresence(value, f.Type)
+				break
+			}
+		}
+	}
+
+	return fields
+}
+
+// asJSONName returns the name of the struct field in a JSON document and whether it was set by the json tag.
+func asJSONName(f reflect.StructField) (string, bool) {
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name, true
+		}
+	}
+	return f.Name, false
+}
+
+// indirectType returns the type that t points to, following any number of pointers.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,119 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssertPresence(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			data:     `{"firstName":"James","lastName":"","address":[]}`,
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			data: `{"firstName":"James","lastName":null,"address":[]}`,
+			expected: &[]Violation{
+				{Field: "Person.LastName", Constraint: "required"},
+			},
+		},
+		{
+			name: "scenario3",
+			data: `{"firstName":"James","address":[{"address1":"755 Crossover Lane","state":"TN","zipcode":"38107"}]}`,
+			expected: &[]Violation{
+				{Field: "Person.LastName", Constraint: "required"},
+				{Field: "Person.Address.Country", Constraint: "required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person := Person{}
+
+			p, err := DecodeJSON([]byte(tt.data), &person)
+			if err != nil {
+				t.Fatalf("DecodeJSON() error = %+v", err)
+			}
+
+			if violations := AssertPresence(person, p); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("AssertPresence() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertForbidden(t *testing.T) {
+	type Account struct {
+		Name string `json:"name" assert:"required=true"`
+		ID   int    `json:"id" assert:"forbidden=true"`
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		expected *[]Violation
+	}{
+		{
+			name:     "scenario1",
+			data:     `{"name":"kirk"}`,
+			expected: &[]Violation{},
+		},
+		{
+			name:     "scenario2",
+			data:     `{"name":"kirk","id":0}`,
+			expected: &[]Violation{{Field: "Account.ID", Constraint: "forbidden"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := Account{}
+
+			p, err := DecodeJSON([]byte(tt.data), &account)
+			if err != nil {
+				t.Fatalf("DecodeJSON() error = %+v", err)
+			}
+
+			if violations := AssertPresence(account, p); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("AssertPresence() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+
+	if violations := Assert(Account{Name: "kirk", ID: 7}); len(violations) != 1 {
+		t.Errorf("Assert() violations = %+v, expected a forbidden violation", violations)
+	}
+
+	if violations := Assert(Account{Name: "kirk"}); len(violations) != 0 {
+		t.Errorf("Assert() violations = %+v, expected none", violations)
+	}
+}
+
+func TestPresencePaths(t *testing.T) {
+	person := Person{}
+	data := `{"lastName":"Kirk","address":[{"city":"Memphis"},{"city":"Austin","location":{"longitude":1}}]}`
+
+	p, err := DecodeJSON([]byte(data), &person)
+	if err != nil {
+		t.Fatalf("DecodeJSON() error = %+v", err)
+	}
+
+	expected := []string{
+		"Person.LastName",
+		"Person.Address",
+		"Person.Address.City",
+		"Person.Address.Location",
+		"Person.Address.Location.Longitude",
+	}
+
+	if actual := p.Paths(reflect.TypeOf(person)); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Paths() = %+v, expected %+v", actual, expected)
+	}
+}
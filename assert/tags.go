@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TagError describes an assert tag that can't be used, such as a pattern that doesn't compile. Field is the path of
+// the field, e.g. Person.ZipCode, and Constraint the constraint of the tag at fault.
+type TagError struct {
+	Field      string
+	Constraint string
+	Err        error
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("assert: invalid %s tag on %s: %v", e.Constraint, e.Field, e.Err)
+}
+
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+// The tagChecks map contains the checks of the tag values of the constraints that can be checked before any value
+// is asserted, given the type t of the field.
+var tagChecks = map[string]func(assertions map[string]string, t reflect.Type) error{
+	"pattern": func(assertions map[string]string, t reflect.Type) error {
+		_, err := compilePattern(assertions)
+		return err
+	},
+	"enum": checkEnum,
+}
+
+// CheckTags checks the assert tags of the struct ifc, or of the struct it points to, and of the structs nested within
+// it. It returns a TagError for each tag that isn't a key and value pair, names an unknown constraint or has a value
+// the constraint can't use, joined with errors.Join. It's meant to be called from a test or during initialization so
+// that a typo in a tag is found before any value is asserted.
+func CheckTags(ifc interface{}) error {
+	errs := make([]error, 0)
+	checkTags(reflect.TypeOf(ifc), "", map[reflect.Type]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+func checkTags(t reflect.Type, path string, seen map[reflect.Type]bool, errs *[]error) {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct || t == timeType || seen[t] {
+		return
+	}
+
+	seen[t] = true
+	path = asPath(path, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		*errs = append(*errs, fieldTagErrors(f, asQualifiedPath(path, f.Name))...)
+		checkTags(f.Type, path, seen, errs)
+	}
+}
+
+// The checkedTypes map records the struct types whose tags were checked when a struct of the type was first asserted.
+var checkedTypes sync.Map
+
+// checkTypeTags checks the tags of the fields of the struct type t the first time a struct of the type is asserted,
+// and logs each TagError once. The constraints at fault are skipped rather than reported as violations.
+func checkTypeTags(t reflect.Type) {
+	if _, checked := checkedTypes.LoadOrStore(t, true); checked {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		for _, err := range fieldTagErrors(t.Field(i), asQualifiedPath(t.Name(), t.Field(i).Name)) {
+			log.Printf("%v", err)
+		}
+	}
+}
+
+// fieldTagErrors returns the TagError of each faulty constraint in the assert tag of the field f named name.
+func fieldTagErrors(f reflect.StructField, name string) []error {
+	errs := make([]error, 0)
+	tag, ok := f.Tag.Lookup("assert")
+
+	if !ok {
+		return errs
+	}
+
+	for _, pair := range asPairs(tag) {
+		if _, _, err := asKeyValue(pair); err != nil {
+			errs = append(errs, &TagError{Field: name, Constraint: pair, Err: err})
+		}
+	}
+
+	assertions := asAssertions(f.Tag)
+	constraints := make([]string, 0, len(assertions))
+
+	for constraint := range assertions {
+		constraints = append(constraints, constraint)
+	}
+
+	sort.Strings(constraints)
+
+	for _, constraint := range constraints {
+		if strings.Contains(constraint, ";") || constraint == "" {
+			continue
+		}
+
+		if _, ok := fieldFns[constraint]; !ok {
+			if _, ok := assertFns[constraint]; !ok {
+				errs = append(errs, &TagError{Field: name, Constraint: constraint, Err: errors.New("unknown constraint")})
+				continue
+			}
+		}
+
+		if check, ok := tagChecks[constraint]; ok {
+			if err := check(assertions, f.Type); err != nil {
+				errs = append(errs, &TagError{Field: name, Constraint: constraint, Err: err})
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,73 @@
+package assert
+
+import (
+	"encoding"
+	"fmt"
+	"log"
+	"reflect"
+)
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// asStringValue returns the text held by val for the string constraints, dereferencing pointers. The text of a type
+// implementing encoding.TextMarshaler is its text form. Otherwise the text of a string-kinded type, such as
+// type Sku string, is its value, the text of a byte slice is its bytes and the text of a type implementing
+// fmt.Stringer is its String method's result unless it is a number. It returns false for other types and nil
+// pointers.
+func asStringValue(val reflect.Value) (string, bool) {
+	val = indirect(val)
+
+	if !val.IsValid() {
+		return "", false
+	}
+
+	if m, ok := asInterface(val, textMarshalerType); ok {
+		text, err := m.(encoding.TextMarshaler).MarshalText()
+
+		if err != nil {
+			log.Printf("%s:%+v", "unable to marshal value to text", err)
+			return "", false
+		}
+
+		return string(text), true
+	}
+
+	switch {
+	case val.Kind() == reflect.String:
+		return val.String(), true
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8:
+		return string(val.Bytes()), true
+	}
+
+	// the text of a number is its digits rather than its String method's result, e.g. the name of an enum
+	if isNumber(val) {
+		return "", false
+	}
+
+	if s, ok := asInterface(val, stringerType); ok {
+		return s.(fmt.Stringer).String(), true
+	}
+
+	return "", false
+}
+
+// asInterface returns val, or a pointer to val when the methods are declared on a pointer receiver, if it implements
+// the interface type t.
+func asInterface(val reflect.Value, t reflect.Type) (interface{}, bool) {
+	if !val.CanInterface() {
+		return nil, false
+	}
+
+	if val.Type().Implements(t) {
+		return val.Interface(), true
+	}
+
+	if val.CanAddr() && val.Addr().Type().Implements(t) {
+		return val.Addr().Interface(), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,106 @@
+package assert
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+type Sku string
+
+type Stock struct {
+	Count int
+}
+
+func (s Stock) String() string {
+	return "stock-" + string(rune('0'+s.Count))
+}
+
+type Warp int
+
+func (w Warp) String() string {
+	return "warp-" + string(rune('0'+int(w)))
+}
+
+type Serial struct {
+	Prefix string
+	Number int
+}
+
+func (s *Serial) MarshalText() ([]byte, error) {
+	return []byte(s.Prefix + "-" + string(rune('0'+s.Number))), nil
+}
+
+func TestAssertText(t *testing.T) {
+	type Product struct {
+		Sku     Sku           `assert:"pattern=^[A-Z]{3}-\\d+$,maxlength=8"`
+		Code    []byte        `assert:"pattern=^[a-f0-9]+$,minlength=2"`
+		Label   *string       `assert:"pattern=^[A-Z],charset=alpha|space"`
+		Gateway net.IP        `assert:"pattern=^10\\."`
+		Address netip.Addr    `assert:"format=ipv4"`
+		Stock   Stock         `assert:"pattern=^stock-\\d$"`
+		Serial  Serial        `assert:"pattern=^NCC-\\d$"`
+		Warp    Warp          `assert:"pattern=^x,max=9"`
+		Aliases []Sku         `assert:"maxlength=5"`
+		Parts   map[Sku]Stock `assert:"pattern=^x"`
+	}
+
+	label := "Warp core"
+	other := "warp core!"
+
+	tests := []struct {
+		name     string
+		product  Product
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			product: Product{
+				Sku:     "WRP-1701",
+				Code:    []byte("0a1b"),
+				Label:   &label,
+				Gateway: net.ParseIP("10.0.0.1"),
+				Address: netip.MustParseAddr("192.168.0.1"),
+				Stock:   Stock{Count: 7},
+				Serial:  Serial{Prefix: "NCC", Number: 1},
+				Warp:    9,
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			product: Product{
+				Sku:     "wrp-17010",
+				Code:    []byte("z"),
+				Label:   &other,
+				Gateway: net.ParseIP("192.168.0.1"),
+				Address: netip.MustParseAddr("::1"),
+				Stock:   Stock{Count: 7},
+				Serial:  Serial{Prefix: "NX", Number: 1},
+				Warp:    10,
+			},
+			expected: &[]Violation{
+				{Field: "Product.Sku", Constraint: "pattern"},
+				{Field: "Product.Sku", Constraint: "maxlength"},
+				{Field: "Product.Code", Constraint: "pattern"},
+				{Field: "Product.Code", Constraint: "minlength"},
+				{Field: "Product.Label", Constraint: "pattern"},
+				{Field: "Product.Label", Constraint: "charset", Message: `invalid character '!' (U+0021) at position 9`},
+				{Field: "Product.Gateway", Constraint: "pattern"},
+				{Field: "Product.Address", Constraint: "format", Message: `ParseAddr("::1"): not an IPv4 address`},
+				{Field: "Product.Serial", Constraint: "pattern"},
+				{Field: "Product.Warp", Constraint: "max"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+
+			if violations := Assert(&product); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Clock tells the time constraints, such as past and before=now+24h, what time it is.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock reading the system time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Options configure a Validator.
+type Options struct {
+	// Clock is the clock used by the time constraints. The system clock is used when it is nil.
+	Clock Clock
+
+	// FullMatch makes the pattern constraints match the whole value, as if anchored with ^ and $, unless their tag sets
+	// match=partial. Without it a pattern matches any substring unless its tag sets match=full.
+	FullMatch bool
+}
+
+// Validator asserts structs with the options it was created with. The package-level functions, such as Assert, use
+// a Validator created with the zero Options.
+type Validator struct {
+	clock     Clock
+	fullMatch bool
+}
+
+// defaultValidator is the Validator used by the package-level functions.
+var defaultValidator = New(Options{})
+
+// New returns a Validator configured with opts.
+func New(opts Options) *Validator {
+	v := &Validator{clock: opts.Clock, fullMatch: opts.FullMatch}
+
+	if v.clock == nil {
+		v.clock = systemClock{}
+	}
+
+	return v
+}
+
+// Assert is used to validate a struct's field like the package-level Assert.
+func (v *Validator) Assert(ifc interface{}, groups ...string) []Violation {
+	return v.AssertContext(context.Background(), ifc, groups...)
+}
+
+// AssertContext is used to validate a struct's field like the package-level AssertContext.
+func (v *Validator) AssertContext(ctx context.Context, ifc interface{}, groups ...string) []Violation {
+	violations := make([]Violation, 0)
+	s := v.scope(ctx, &violations, groups)
+	s.walk(reflect.ValueOf(ifc), "", nil, true)
+	return violations
+}
+
+// AssertPresence is used to validate a struct decoded by DecodeJSON like the package-level AssertPresence.
+func (v *Validator) AssertPresence(ifc interface{}, p *Presence, groups ...string) []Violation {
+	return v.AssertPresenceContext(context.Background(), ifc, p, groups...)
+}
+
+// AssertPresenceContext is used to validate a struct decoded by DecodeJSON like the package-level
+// AssertPresenceContext.
+func (v *Validator) AssertPresenceContext(ctx context.Context, ifc interface{}, p *Presence, groups ...string) []Violation {
+	violations := make([]Violation, 0)
+	s := v.scope(ctx, &violations, groups)
+	s.decoded = true
+	s.walk(reflect.ValueOf(ifc), "", p, true)
+	return violations
+}
+
+// AssertPartial is used to validate the fields of a partial update like the package-level AssertPartial.
+func (v *Validator) AssertPartial(ifc interface{}, paths []string, groups ...string) []Violation {
+	return v.AssertPartialContext(context.Background(), ifc, paths, groups...)
+}
+
+// AssertPartialContext is used to validate the fields of a partial update like the package-level
+// AssertPartialContext.
+func (v *Validator) AssertPartialContext(ctx context.Context, ifc interface{}, paths []string, groups ...string) []Violation {
+	violations := make([]Violation, 0)
+	s := v.scope(ctx, &violations, groups)
+	s.paths = make(map[string]bool)
+
+	for _, path := range paths {
+		s.paths[path] = true
+	}
+
+	s.walk(reflect.ValueOf(ifc), "", nil, false)
+	return violations
+}
+
+// scope returns the state of a new run collecting its violations in violations.
+func (v *Validator) scope(ctx context.Context, violations *[]Violation, groups []string) *scope {
+	return &scope{violations: violations, groups: asGroups(groups), ctx: ctx, clock: v.clock, fullMatch: v.fullMatch}
+}
+
+// nowKey is the context key of the time set by WithNow.
+type nowKey struct{}
+
+// WithNow returns a copy of ctx that makes the time constraints of a run use now as the current time, e.g. to check
+// archived records as of the date they were ingested. It takes precedence over the Validator's clock.
+func WithNow(ctx context.Context, now time.Time) context.Context {
+	return context.WithValue(ctx, nowKey{}, now)
+}
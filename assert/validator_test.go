@@ -0,0 +1,100 @@
+package assert
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestValidatorClock(t *testing.T) {
+	type Record struct {
+		Birthdate string    `assert:"format=date,past=true"`
+		Expires   time.Time `assert:"before=now+P90D"`
+	}
+
+	record := Record{
+		Birthdate: "2020-06-01",
+		Expires:   time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		validator *Validator
+		ctx       context.Context
+		expected  *[]Violation
+	}{
+		{
+			name:      "scenario1",
+			validator: New(Options{Clock: fixedClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))}),
+			ctx:       context.Background(),
+			expected:  &[]Violation{},
+		},
+		{
+			name:      "scenario2",
+			validator: New(Options{Clock: fixedClock(time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC))}),
+			ctx:       context.Background(),
+			expected: &[]Violation{
+				{Field: "Record.Birthdate", Constraint: "past", Message: "must be in the past"},
+				{Field: "Record.Expires", Constraint: "before", Message: "must be before now+P90D"},
+			},
+		},
+		{
+			name:      "scenario3",
+			validator: New(Options{Clock: fixedClock(time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC))}),
+			ctx:       WithNow(context.Background(), time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)),
+			expected:  &[]Violation{},
+		},
+		{
+			name:      "scenario4",
+			validator: New(Options{}),
+			ctx:       WithNow(context.Background(), time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)),
+			expected: &[]Violation{
+				{Field: "Record.Birthdate", Constraint: "past", Message: "must be in the past"},
+				{Field: "Record.Expires", Constraint: "before", Message: "must be before now+P90D"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := tt.validator.AssertContext(tt.ctx, record); !reflect.DeepEqual(&violations, tt.expected) {
+				t.Errorf("AssertContext() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidatorContext(t *testing.T) {
+	type Record struct {
+		Birthdate string `json:"birthdate" assert:"required=true,format=date,past=true"`
+	}
+
+	record := Record{}
+
+	p, err := DecodeJSON([]byte(`{"birthdate":"2020-06-01"}`), &record)
+	if err != nil {
+		t.Fatalf("DecodeJSON() error = %+v", err)
+	}
+
+	ctx := WithNow(context.Background(), time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC))
+	expected := []Violation{{Field: "Record.Birthdate", Constraint: "past", Message: "must be in the past"}}
+
+	if violations := AssertPresenceContext(ctx, record, p); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("AssertPresenceContext() violations = %+v, expected %+v", violations, expected)
+	}
+
+	if violations := AssertPartialContext(ctx, record, p.Paths(reflect.TypeOf(record))); !reflect.DeepEqual(violations, expected) {
+		t.Errorf("AssertPartialContext() violations = %+v, expected %+v", violations, expected)
+	}
+
+	if violations := AssertPartial(record, p.Paths(reflect.TypeOf(record))); len(violations) != 0 {
+		t.Errorf("AssertPartial() violations = %+v, expected none", violations)
+	}
+}
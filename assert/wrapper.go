@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"database/sql/driver"
+	"log"
+	"reflect"
+)
+
+// WrapperFunc unwraps an optional value of a type registered with RegisterWrapper. v holds a value of that type. It
+// returns the wrapped value and whether it is set.
+type WrapperFunc func(v interface{}) (interface{}, bool)
+
+// The wrapperFns map contains the functions unwrapping the optional-wrapper types registered with RegisterWrapper.
+var wrapperFns = map[reflect.Type]WrapperFunc{}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// RegisterWrapper registers an optional-wrapper type, the type of v, whose fields are asserted through the value they
+// wrap, e.g. an Optional[T] struct. An unset value counts as absent for required and skips the other constraints. It
+// replaces any function registered for the type and takes precedence over driver.Valuer. It should be called during
+// initialization.
+func RegisterWrapper(v interface{}, fn WrapperFunc) {
+	wrapperFns[reflect.TypeOf(v)] = fn
+}
+
+// unwrap returns the value wrapped by v when v is a registered optional-wrapper type or implements driver.Valuer, as
+// the database/sql Null types do. The zero Value is returned for an unset value, such as a sql.NullString with Valid
+// set to false. It returns false if v isn't a wrapper, including a nil pointer to one, whose Value method would panic.
+func unwrap(v reflect.Value) (reflect.Value, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return v, false
+	}
+
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return v, false
+	}
+
+	if fn, ok := wrapperFns[v.Type()]; ok {
+		wrapped, set := fn(v.Interface())
+
+		if !set || wrapped == nil {
+			return reflect.Value{}, true
+		}
+
+		return reflect.ValueOf(wrapped), true
+	}
+
+	valuer, ok := asInterface(v, valuerType)
+
+	if !ok {
+		return v, false
+	}
+
+	wrapped, err := valuer.(driver.Valuer).Value()
+
+	if err != nil {
+		log.Printf("%s:%+v", "unable to get the value of a driver.Valuer", err)
+		return reflect.Value{}, true
+	}
+
+	if wrapped == nil {
+		return reflect.Value{}, true
+	}
+
+	// a Valuer returning a value of its own type is asserted as is
+	if w := reflect.ValueOf(wrapped); w.Type() != v.Type() {
+		return w, true
+	}
+
+	return v, false
+}
@@ -0,0 +1,132 @@
+package assert
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// Optional is an optional-wrapper type registered with RegisterWrapper.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Cents is a custom driver.Valuer holding an amount in cents.
+type Cents struct {
+	Amount int64
+}
+
+func (c Cents) Value() (driver.Value, error) {
+	return c.Amount, nil
+}
+
+func init() {
+	RegisterWrapper(Optional[string]{}, func(v interface{}) (interface{}, bool) {
+		o := v.(Optional[string])
+		return o.Value, o.Set
+	})
+}
+
+func TestAssertWrappers(t *testing.T) {
+	type Order struct {
+		Reference sql.NullString   `assert:"required=true,pattern=^ORD-\\d+$"`
+		Quantity  sql.NullInt64    `assert:"min=1,max=10"`
+		Shipped   sql.NullTime     `assert:"past=true"`
+		Method    sql.NullString   `assert:"oneof=card|cash"`
+		Voucher   sql.NullString   `assert:"required_if=Method card"`
+		Total     Cents            `assert:"min=100"`
+		Note      Optional[string] `assert:"required=true,maxlength=5"`
+	}
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		order    Order
+		expected *[]Violation
+	}{
+		{
+			name: "scenario1",
+			order: Order{
+				Reference: sql.NullString{String: "ORD-1701", Valid: true},
+				Quantity:  sql.NullInt64{Int64: 2, Valid: true},
+				Shipped:   sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+				Method:    sql.NullString{String: "cash", Valid: true},
+				Total:     Cents{Amount: 1999},
+				Note:      Optional[string]{Value: "gift", Set: true},
+			},
+			expected: &[]Violation{},
+		},
+		{
+			name: "scenario2",
+			order: Order{
+				Reference: sql.NullString{String: "ORD-1701"},
+				Quantity:  sql.NullInt64{Int64: 0},
+				Shipped:   sql.NullTime{Time: now.Add(time.Hour)},
+				Method:    sql.NullString{String: "card", Valid: true},
+				Voucher:   sql.NullString{String: "FREE"},
+				Total:     Cents{Amount: 99},
+				Note:      Optional[string]{Value: "gift"},
+			},
+			expected: &[]Violation{
+				{Field: "Order.Reference", Constraint: "required"},
+				{Field: "Order.Voucher", Constraint: "required_if", Message: "required when Method is card"},
+				{Field: "Order.Total", Constraint: "min"},
+				{Field: "Order.Note", Constraint: "required"},
+			},
+		},
+		{
+			name: "scenario3",
+			order: Order{
+				Reference: sql.NullString{String: "1701", Valid: true},
+				Quantity:  sql.NullInt64{Int64: 11, Valid: true},
+				Shipped:   sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+				Method:    sql.NullString{String: "cheque", Valid: true},
+				Total:     Cents{Amount: 100},
+				Note:      Optional[string]{Value: "birthday", Set: true},
+			},
+			expected: &[]Violation{
+				{Field: "Order.Reference", Constraint: "pattern"},
+				{Field: "Order.Quantity", Constraint: "max"},
+				{Field: "Order.Shipped", Constraint: "past", Message: "must be in the past"},
+				{Field: "Order.Method", Constraint: "oneof", Message: "must be one of card, cash"},
+				{Field: "Order.Note", Constraint: "maxlength"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := Assert(tt.order); !sameViolations(violations, *tt.expected) {
+				t.Errorf("Assert() violations = %+v, expected %+v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssertNilWrappers(t *testing.T) {
+	type Invoice struct {
+		Reference *sql.NullString `assert:"required=true"`
+		Discount  *sql.NullInt64  `assert:"forbidden=true"`
+		Note      *sql.NullString `assert:"maxlength=5"`
+	}
+
+	expected := []Violation{{Field: "Invoice.Reference", Constraint: "required"}}
+
+	if violations := Assert(Invoice{}); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+
+	expected = []Violation{{Field: "Invoice.Discount", Constraint: "forbidden"}, {Field: "Invoice.Note", Constraint: "maxlength"}}
+	invoice := Invoice{
+		Reference: &sql.NullString{String: "INV-1701", Valid: true},
+		Discount:  &sql.NullInt64{Int64: 10, Valid: true},
+		Note:      &sql.NullString{String: "express", Valid: true},
+	}
+
+	if violations := Assert(invoice); !sameViolations(violations, expected) {
+		t.Errorf("Assert() violations = %+v, expected %+v", violations, expected)
+	}
+}